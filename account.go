@@ -0,0 +1,270 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+type FindBillingHistoryRequest struct {
+	Page    int
+	PerPage int
+}
+
+type FindAllInvoicesRequest struct {
+	Page    int
+	PerPage int
+}
+
+type FindInvoiceItemsRequest struct {
+	InvoiceUUID string
+	Page        int
+	PerPage     int
+}
+
+// InvoiceFileRequest writes the invoice to Path when saved to a file.
+type InvoiceFileRequest struct {
+	InvoiceUUID string
+	InvoiceFormat
+	Path string
+}
+
+type DropletLimit struct {
+	Limit     int
+	Used      int
+	Remaining int
+}
+
+// Invoice formats
+type InvoiceFormat int
+
+const (
+	CSVInvoice InvoiceFormat = iota
+	PDFInvoice
+)
+
+func (inf InvoiceFormat) String() string {
+	names := [...]string{
+		"csv",
+		"pdf",
+	}
+	if inf < CSVInvoice || inf > PDFInvoice {
+		return "That is not an invoice format"
+	}
+	return names[inf]
+}
+
+type AccountClient interface {
+	Get(context.Context) (*godo.Account, *godo.Response, error)
+}
+
+type BalanceClient interface {
+	Get(context.Context) (*godo.Balance, *godo.Response, error)
+}
+
+type BillingHistoryClient interface {
+	List(context.Context, *godo.ListOptions) (*godo.BillingHistory, *godo.Response, error)
+}
+
+type InvoiceClient interface {
+	Get(context.Context, string, *godo.ListOptions) (*godo.Invoice, *godo.Response, error)
+	GetPDF(context.Context, string) ([]byte, *godo.Response, error)
+	GetCSV(context.Context, string) ([]byte, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) (*godo.InvoiceList, *godo.Response, error)
+	GetSummary(context.Context, string) (*godo.InvoiceSummary, *godo.Response, error)
+}
+
+type Account struct {
+	client   AccountClient
+	balance  BalanceClient
+	billing  BillingHistoryClient
+	invoices InvoiceClient
+	droplets DropletClient
+}
+
+func NewAccC(pat string) Account {
+	client := Authenticate(pat)
+	return Account{
+		client:   client.Account,
+		balance:  client.Balance,
+		billing:  client.BillingHistory,
+		invoices: client.Invoices,
+		droplets: client.Droplets,
+	}
+}
+
+// GetAccount returns the account info, including its droplet, volume and
+// reserved IP limits.
+func (a *Account) GetAccount() (*godo.Account, error) {
+
+	ctx := context.TODO()
+
+	account, _, err := a.client.Get(ctx)
+	if err != nil {
+		return nil, errors.New("Unable to get account. Godo error: " + err.Error())
+	}
+
+	return account, nil
+}
+
+// GetDropletLimit compares the account droplet limit with the droplets
+// already running on it.
+func (a *Account) GetDropletLimit() (*DropletLimit, error) {
+
+	account, err := a.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	used := 0
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := a.droplets.List(ctx, opt)
+		if err != nil {
+			return nil, errors.New("Unable to count droplets. Godo error: " + err.Error())
+		}
+		used += len(droplets)
+
+		if isLastPage(resp) {
+			break
+		}
+		opt.Page++
+	}
+
+	return &DropletLimit{
+		Limit:     account.DropletLimit,
+		Used:      used,
+		Remaining: account.DropletLimit - used,
+	}, nil
+}
+
+// CheckDropletLimit returns an error when creating count more droplets would
+// go over the account droplet limit.
+func (a *Account) CheckDropletLimit(count int) (*DropletLimit, error) {
+
+	limit, err := a.GetDropletLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	if count > limit.Remaining {
+		return limit, errors.New("Unable to create " + strconv.Itoa(count) + " droplets. " + strconv.Itoa(limit.Used) + " of the account limit of " + strconv.Itoa(limit.Limit) + " droplets are in use")
+	}
+
+	return limit, nil
+}
+
+func (a *Account) GetBalance() (*godo.Balance, error) {
+
+	ctx := context.TODO()
+
+	balance, _, err := a.balance.Get(ctx)
+	if err != nil {
+		return nil, errors.New("Unable to get balance. Godo error: " + err.Error())
+	}
+
+	return balance, nil
+}
+
+func (a *Account) GetBillingHistory(fbhr FindBillingHistoryRequest) ([]godo.BillingHistoryEntry, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fbhr.Page,
+		PerPage: fbhr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	history, _, err := a.billing.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get billing history. Godo error: " + err.Error())
+	}
+
+	return history.BillingHistory, nil
+}
+
+// GetAllInvoices returns the past invoices along with a preview of the
+// current month's invoice.
+func (a *Account) GetAllInvoices(fair FindAllInvoicesRequest) (*godo.InvoiceList, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fair.Page,
+		PerPage: fair.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	invoices, _, err := a.invoices.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all invoices. Godo error: " + err.Error())
+	}
+
+	return invoices, nil
+}
+
+func (a *Account) GetInvoiceItems(fiir FindInvoiceItemsRequest) ([]godo.InvoiceItem, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fiir.Page,
+		PerPage: fiir.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	invoice, _, err := a.invoices.Get(ctx, fiir.InvoiceUUID, opt)
+	if err != nil {
+		return nil, errors.New("Invoice with uuid: " + fiir.InvoiceUUID + ", was not found. Godo error: " + err.Error())
+	}
+
+	return invoice.InvoiceItems, nil
+}
+
+func (a *Account) GetInvoiceSummary(uuid string) (*godo.InvoiceSummary, error) {
+
+	ctx := context.TODO()
+
+	summary, _, err := a.invoices.GetSummary(ctx, uuid)
+	if err != nil {
+		return nil, errors.New("Unable to get summary of invoice with uuid: " + uuid + ". Godo error: " + err.Error())
+	}
+
+	return summary, nil
+}
+
+func (a *Account) GetInvoiceFile(ifr InvoiceFileRequest) ([]byte, error) {
+
+	ctx := context.TODO()
+
+	var file []byte
+	var err error
+	if ifr.InvoiceFormat == PDFInvoice {
+		file, _, err = a.invoices.GetPDF(ctx, ifr.InvoiceUUID)
+	} else {
+		file, _, err = a.invoices.GetCSV(ctx, ifr.InvoiceUUID)
+	}
+	if err != nil {
+		return nil, errors.New("Unable to get " + ifr.InvoiceFormat.String() + " of invoice with uuid: " + ifr.InvoiceUUID + ". Godo error: " + err.Error())
+	}
+
+	return file, nil
+}
+
+// WriteInvoiceFile saves the invoice to Path with 0600 permissions.
+func (a *Account) WriteInvoiceFile(ifr InvoiceFileRequest) error {
+
+	file, err := a.GetInvoiceFile(ifr)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(ifr.Path, file, 0600)
+	if err != nil {
+		return errors.New("Unable to write invoice to " + ifr.Path + ". " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestAccount = godo.Account{
+	DropletLimit: 2,
+	VolumeLimit:  10,
+	Email:        "finance@example.com",
+	UUID:         "b6fr89dbf6d9156cace5f3c78dc9851d957381ef",
+	Status:       "active",
+}
+
+var TestBalance = godo.Balance{
+	MonthToDateBalance: "23.44",
+	AccountBalance:     "12.23",
+	MonthToDateUsage:   "11.21",
+	GeneratedAt:        time.Date(2019, 7, 9, 15, 1, 12, 0, time.UTC),
+}
+
+var TestBillingHistory = godo.BillingHistory{
+	BillingHistory: []godo.BillingHistoryEntry{
+		{Description: "Invoice for May 2018", Amount: "12.34", Date: time.Date(2018, 6, 1, 8, 44, 38, 0, time.UTC), Type: "Invoice"},
+	},
+}
+
+var TestInvoiceList = godo.InvoiceList{
+	Invoices: []godo.InvoiceListItem{
+		{InvoiceUUID: "22737513-0ea7-4206-8ceb-98a575af7681", Amount: "12.34", InvoicePeriod: "2019-12"},
+	},
+}
+
+var TestInvoiceCSV = []byte("product,group_description,description,hours,start,end,USD,project_name,category\n")
+
+func TestGetDropletLimit(t *testing.T) {
+
+	aClient := NewAccC(TestPAT)
+	aClient.client = &MockGodoAccountSvc{}
+	aClient.droplets = &MockGodoDropletSvc{}
+
+	expected := &DropletLimit{Limit: 2, Used: 1, Remaining: 1}
+	returned, _ := aClient.GetDropletLimit()
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCheckDropletLimit(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		aClient := NewAccC(TestPAT)
+		aClient.client = &MockGodoAccountSvc{}
+		aClient.droplets = &MockGodoDropletSvc{}
+
+		expectedError := "Unable to create 2 droplets. 1 of the account limit of 2 droplets are in use"
+		_, returnedError := aClient.CheckDropletLimit(2)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestGetBalance(t *testing.T) {
+
+	aClient := NewAccC(TestPAT)
+	aClient.balance = &MockGodoBalanceSvc{}
+
+	expected := &TestBalance
+	returned, _ := aClient.GetBalance()
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetBillingHistory(t *testing.T) {
+
+	aClient := NewAccC(TestPAT)
+	aClient.billing = &MockGodoBillingHistorySvc{}
+
+	expected := TestBillingHistory.BillingHistory
+	returned, _ := aClient.GetBillingHistory(FindBillingHistoryRequest{Page: 1, PerPage: 20})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetAllInvoices(t *testing.T) {
+
+	aClient := NewAccC(TestPAT)
+	aClient.invoices = &MockGodoInvoiceSvc{}
+
+	expected := &TestInvoiceList
+	returned, _ := aClient.GetAllInvoices(FindAllInvoicesRequest{Page: 1, PerPage: 20})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestWriteInvoiceFile(t *testing.T) {
+
+	aClient := NewAccC(TestPAT)
+	aClient.invoices = &MockGodoInvoiceSvc{}
+
+	path := filepath.Join(t.TempDir(), "invoice.csv")
+	err := aClient.WriteInvoiceFile(InvoiceFileRequest{InvoiceUUID: "22737513-0ea7-4206-8ceb-98a575af7681", InvoiceFormat: CSVInvoice, Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	returned, _ := os.ReadFile(path)
+	if !reflect.DeepEqual(TestInvoiceCSV, returned) {
+		t.Errorf("expected %s\n , returned, %s\n ", TestInvoiceCSV, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Unable to get pdf of invoice with uuid: 22737513-0ea7-4206-8ceb-98a575af7681. Godo error: " + TestError
+		returnedError := aClient.WriteInvoiceFile(InvoiceFileRequest{InvoiceUUID: "22737513-0ea7-4206-8ceb-98a575af7681", InvoiceFormat: PDFInvoice, Path: path})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoAccountSvc struct{}
+
+func (m *MockGodoAccountSvc) Get(context.Context) (*godo.Account, *godo.Response, error) {
+	return &TestAccount, nil, nil
+}
+
+type MockGodoBalanceSvc struct{}
+
+func (m *MockGodoBalanceSvc) Get(context.Context) (*godo.Balance, *godo.Response, error) {
+	return &TestBalance, nil, nil
+}
+
+type MockGodoBillingHistorySvc struct{}
+
+func (m *MockGodoBillingHistorySvc) List(context.Context, *godo.ListOptions) (*godo.BillingHistory, *godo.Response, error) {
+	return &TestBillingHistory, nil, nil
+}
+
+type MockGodoInvoiceSvc struct{}
+
+func (m *MockGodoInvoiceSvc) Get(context.Context, string, *godo.ListOptions) (*godo.Invoice, *godo.Response, error) {
+	return &godo.Invoice{}, nil, nil
+}
+
+func (m *MockGodoInvoiceSvc) GetPDF(context.Context, string) ([]byte, *godo.Response, error) {
+	return nil, nil, errors.New(TestError)
+}
+
+func (m *MockGodoInvoiceSvc) GetCSV(context.Context, string) ([]byte, *godo.Response, error) {
+	return TestInvoiceCSV, nil, nil
+}
+
+func (m *MockGodoInvoiceSvc) List(context.Context, *godo.ListOptions) (*godo.InvoiceList, *godo.Response, error) {
+	return &TestInvoiceList, nil, nil
+}
+
+func (m *MockGodoInvoiceSvc) GetSummary(context.Context, string) (*godo.InvoiceSummary, *godo.Response, error) {
+	return &godo.InvoiceSummary{}, nil, nil
+}
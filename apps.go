@@ -0,0 +1,326 @@
+package dog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"gopkg.in/yaml.v3"
+)
+
+type CreateAppRequest struct {
+	Spec      *godo.AppSpec
+	ProjectID string
+}
+
+type FindAllAppsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type DeleteAppRequest struct {
+	ID string
+}
+
+type CreateDeploymentRequest struct {
+	AppID      string
+	ForceBuild bool
+}
+
+type FindDeploymentsRequest struct {
+	AppID   string
+	Page    int
+	PerPage int
+}
+
+type AppLogsRequest struct {
+	AppID        string
+	DeploymentID string
+	Component    string
+	AppLogKind
+	TailLines int
+}
+
+// RollbackRequest redeploys the spec that DeploymentID was built from.
+type RollbackRequest struct {
+	AppID        string
+	DeploymentID string
+}
+
+type WaitForDeploymentRequest struct {
+	AppID        string
+	DeploymentID string
+	Timeout      time.Duration
+}
+
+// App log kinds
+type AppLogKind int
+
+const (
+	BuildLog AppLogKind = iota
+	DeployLog
+	RunLog
+)
+
+func (alk AppLogKind) String() string {
+	names := [...]string{
+		"BUILD",
+		"DEPLOY",
+		"RUN",
+	}
+	if alk < BuildLog || alk > RunLog {
+		return "That is not an app log kind"
+	}
+	return names[alk]
+}
+
+type AppClient interface {
+	Create(context.Context, *godo.AppCreateRequest) (*godo.App, *godo.Response, error)
+	Get(context.Context, string) (*godo.App, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]*godo.App, *godo.Response, error)
+	Update(context.Context, string, *godo.AppUpdateRequest) (*godo.App, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	GetDeployment(context.Context, string, string) (*godo.Deployment, *godo.Response, error)
+	ListDeployments(context.Context, string, *godo.ListOptions) ([]*godo.Deployment, *godo.Response, error)
+	CreateDeployment(context.Context, string, ...*godo.DeploymentCreateRequest) (*godo.Deployment, *godo.Response, error)
+	GetLogs(context.Context, string, string, string, godo.AppLogType, bool, int) (*godo.AppLogs, *godo.Response, error)
+}
+
+type App struct {
+	client       AppClient
+	httpClient   *http.Client
+	pollInterval time.Duration
+}
+
+func NewAC(pat string) App {
+	client := Authenticate(pat)
+	return App{client: client.Apps, httpClient: http.DefaultClient, pollInterval: 10 * time.Second}
+}
+
+// LoadAppSpec reads an app spec from a YAML or JSON file.
+func LoadAppSpec(path string) (*godo.AppSpec, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("Unable to read app spec " + path + ". " + err.Error())
+	}
+
+	// app specs use the same keys in YAML and JSON, so YAML is decoded
+	// generically and handed to godo's JSON tags
+	var raw interface{}
+	err = yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, errors.New("Unable to parse app spec " + path + ". " + err.Error())
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.New("Unable to parse app spec " + path + ". " + err.Error())
+	}
+
+	spec := &godo.AppSpec{}
+	err = json.Unmarshal(encoded, spec)
+	if err != nil {
+		return nil, errors.New("Unable to parse app spec " + path + ". " + err.Error())
+	}
+
+	return spec, nil
+}
+
+func (a *App) CreateApp(car CreateAppRequest) (*godo.App, error) {
+
+	create := &godo.AppCreateRequest{
+		Spec:      car.Spec,
+		ProjectID: car.ProjectID,
+	}
+
+	ctx := context.TODO()
+
+	app, _, err := a.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create app. Godo error: " + err.Error())
+	}
+
+	return app, nil
+}
+
+func (a *App) GetApp(id string) (*godo.App, error) {
+
+	ctx := context.TODO()
+
+	app, _, err := a.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("App with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return app, nil
+}
+
+func (a *App) GetAllApps(faar FindAllAppsRequest) ([]*godo.App, error) {
+
+	opt := &godo.ListOptions{
+		Page:    faar.Page,
+		PerPage: faar.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	apps, _, err := a.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all apps. Godo error: " + err.Error())
+	}
+
+	return apps, nil
+}
+
+func (a *App) DeleteApp(dar DeleteAppRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := a.client.Delete(ctx, dar.ID)
+	if err != nil {
+		return errors.New("Unable to delete app with id: " + dar.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (a *App) CreateDeployment(cdr CreateDeploymentRequest) (*godo.Deployment, error) {
+
+	ctx := context.TODO()
+
+	deployment, _, err := a.client.CreateDeployment(ctx, cdr.AppID, &godo.DeploymentCreateRequest{ForceBuild: cdr.ForceBuild})
+	if err != nil {
+		return nil, errors.New("Unable to create deployment for app with id: " + cdr.AppID + ". Godo error: " + err.Error())
+	}
+
+	return deployment, nil
+}
+
+func (a *App) GetDeployment(appID string, deploymentID string) (*godo.Deployment, error) {
+
+	ctx := context.TODO()
+
+	deployment, _, err := a.client.GetDeployment(ctx, appID, deploymentID)
+	if err != nil {
+		return nil, errors.New("Deployment with id: " + deploymentID + ", was not found. Godo error: " + err.Error())
+	}
+
+	return deployment, nil
+}
+
+func (a *App) GetDeployments(fdr FindDeploymentsRequest) ([]*godo.Deployment, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fdr.Page,
+		PerPage: fdr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	deployments, _, err := a.client.ListDeployments(ctx, fdr.AppID, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get deployments for app with id: " + fdr.AppID + ". Godo error: " + err.Error())
+	}
+
+	return deployments, nil
+}
+
+func (a *App) GetLogURLs(alr AppLogsRequest) (*godo.AppLogs, error) {
+
+	ctx := context.TODO()
+
+	logs, _, err := a.client.GetLogs(ctx, alr.AppID, alr.DeploymentID, alr.Component, godo.AppLogType(alr.AppLogKind.String()), false, alr.TailLines)
+	if err != nil {
+		return nil, errors.New("Unable to get logs for app with id: " + alr.AppID + ". Godo error: " + err.Error())
+	}
+
+	return logs, nil
+}
+
+// GetLogs downloads the historic logs of a deployment.
+func (a *App) GetLogs(alr AppLogsRequest) ([]byte, error) {
+
+	logs, err := a.GetLogURLs(alr)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	for _, url := range logs.HistoricURLs {
+		resp, err := a.httpClient.Get(url)
+		if err != nil {
+			return nil, errors.New("Unable to download logs for app with id: " + alr.AppID + ". " + err.Error())
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.New("Unable to download logs for app with id: " + alr.AppID + ". " + err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New("Unable to download logs for app with id: " + alr.AppID + ". Status: " + resp.Status)
+		}
+		content = append(content, body...)
+	}
+
+	return content, nil
+}
+
+// Rollback updates the app to the spec of an earlier deployment, which
+// starts a new deployment of that spec.
+func (a *App) Rollback(rr RollbackRequest) (*godo.App, error) {
+
+	deployment, err := a.GetDeployment(rr.AppID, rr.DeploymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deployment.Spec == nil {
+		return nil, errors.New("Deployment with id: " + rr.DeploymentID + " has no spec to roll back to")
+	}
+
+	ctx := context.TODO()
+
+	app, _, err := a.client.Update(ctx, rr.AppID, &godo.AppUpdateRequest{Spec: deployment.Spec})
+	if err != nil {
+		return nil, errors.New("Unable to roll back app with id: " + rr.AppID + ". Godo error: " + err.Error())
+	}
+
+	return app, nil
+}
+
+// WaitForDeployment polls a deployment until it is ACTIVE, reaches ERROR or
+// another terminal phase, or Timeout passes.
+func (a *App) WaitForDeployment(wfdr WaitForDeploymentRequest) (*godo.Deployment, error) {
+
+	ctx := context.TODO()
+	if wfdr.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wfdr.Timeout)
+		defer cancel()
+	}
+
+	for {
+		deployment, _, err := a.client.GetDeployment(ctx, wfdr.AppID, wfdr.DeploymentID)
+		if err != nil {
+			return nil, errors.New("Deployment with id: " + wfdr.DeploymentID + ", was not found. Godo error: " + err.Error())
+		}
+
+		switch deployment.Phase {
+		case godo.DeploymentPhase_Active:
+			return deployment, nil
+		case godo.DeploymentPhase_Error, godo.DeploymentPhase_Canceled, godo.DeploymentPhase_Superseded:
+			return deployment, errors.New("Deployment with id: " + wfdr.DeploymentID + " finished in phase: " + string(deployment.Phase))
+		}
+
+		select {
+		case <-ctx.Done():
+			return deployment, errors.New("Timed out waiting for deployment with id: " + wfdr.DeploymentID + " to be active")
+		case <-time.After(a.pollInterval):
+		}
+	}
+}
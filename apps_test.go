@@ -0,0 +1,208 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestAppSpec = godo.AppSpec{
+	Name:   "dog-app",
+	Region: "nyc",
+	Services: []*godo.AppServiceSpec{
+		{
+			Name:             "web",
+			HTTPPort:         8080,
+			InstanceCount:    2,
+			InstanceSizeSlug: "basic-xxs",
+			Image: &godo.ImageSourceSpec{
+				RegistryType: godo.ImageSourceSpecRegistryType_DOCR,
+				Repository:   "web",
+				Tag:          "latest",
+			},
+		},
+	},
+}
+
+var TestAppSpecYAML = `name: dog-app
+region: nyc
+services:
+  - name: web
+    http_port: 8080
+    instance_count: 2
+    instance_size_slug: basic-xxs
+    image:
+      registry_type: DOCR
+      repository: web
+      tag: latest
+`
+
+var TestDeployment = godo.Deployment{
+	ID:    "b6bdf840-2854-4f87-a36c-5f231c617c84",
+	Spec:  &TestAppSpec,
+	Phase: godo.DeploymentPhase_Active,
+}
+
+var TestDeployments = []*godo.Deployment{&TestDeployment}
+
+var TestApp = godo.App{
+	ID:               "c2a93513-8d9b-4223-9d61-5e7272c81cf5",
+	Spec:             &TestAppSpec,
+	ActiveDeployment: &TestDeployment,
+}
+
+var TestApps = []*godo.App{&TestApp}
+
+func newTestApp() App {
+	aClient := NewAC(TestPAT)
+	aClient.client = &MockGodoAppSvc{}
+	aClient.pollInterval = time.Millisecond
+	return aClient
+}
+
+func TestLoadAppSpec(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	os.WriteFile(path, []byte(TestAppSpecYAML), 0600)
+
+	expected := &TestAppSpec
+	returned, err := LoadAppSpec(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateApp(t *testing.T) {
+
+	aClient := newTestApp()
+
+	expected := &TestApp
+	returned, _ := aClient.CreateApp(CreateAppRequest{Spec: &TestAppSpec})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetDeployments(t *testing.T) {
+
+	aClient := newTestApp()
+
+	expected := TestDeployments
+	returned, _ := aClient.GetDeployments(FindDeploymentsRequest{AppID: TestApp.ID})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetLogs(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("build complete\n"))
+	}))
+	defer server.Close()
+
+	aClient := newTestApp()
+	aClient.client = &MockGodoAppSvc{logURL: server.URL}
+
+	expected := []byte("build complete\n")
+	returned, err := aClient.GetLogs(AppLogsRequest{AppID: TestApp.ID, DeploymentID: TestDeployment.ID, AppLogKind: BuildLog})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %s\n , returned, %s\n ", expected, returned)
+	}
+
+}
+
+func TestRollback(t *testing.T) {
+
+	aClient := newTestApp()
+
+	expected := &TestApp
+	returned, _ := aClient.Rollback(RollbackRequest{AppID: TestApp.ID, DeploymentID: TestDeployment.ID})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestWaitForDeployment(t *testing.T) {
+
+	aClient := newTestApp()
+
+	expected := &TestDeployment
+	returned, _ := aClient.WaitForDeployment(WaitForDeploymentRequest{AppID: TestApp.ID, DeploymentID: TestDeployment.ID, Timeout: time.Second})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteApp(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		aClient := newTestApp()
+
+		expectedError := "Unable to delete app with id: " + TestApp.ID + ". Godo error: " + TestError
+		returnedError := aClient.DeleteApp(DeleteAppRequest{ID: TestApp.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoAppSvc struct {
+	logURL string
+}
+
+func (m *MockGodoAppSvc) Create(context.Context, *godo.AppCreateRequest) (*godo.App, *godo.Response, error) {
+	return &TestApp, nil, nil
+}
+
+func (m *MockGodoAppSvc) Get(context.Context, string) (*godo.App, *godo.Response, error) {
+	return &TestApp, nil, nil
+}
+
+func (m *MockGodoAppSvc) List(context.Context, *godo.ListOptions) ([]*godo.App, *godo.Response, error) {
+	return TestApps, nil, nil
+}
+
+func (m *MockGodoAppSvc) Update(context.Context, string, *godo.AppUpdateRequest) (*godo.App, *godo.Response, error) {
+	return &TestApp, nil, nil
+}
+
+func (m *MockGodoAppSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoAppSvc) GetDeployment(context.Context, string, string) (*godo.Deployment, *godo.Response, error) {
+	return &TestDeployment, nil, nil
+}
+
+func (m *MockGodoAppSvc) ListDeployments(context.Context, string, *godo.ListOptions) ([]*godo.Deployment, *godo.Response, error) {
+	return TestDeployments, nil, nil
+}
+
+func (m *MockGodoAppSvc) CreateDeployment(context.Context, string, ...*godo.DeploymentCreateRequest) (*godo.Deployment, *godo.Response, error) {
+	return &TestDeployment, nil, nil
+}
+
+func (m *MockGodoAppSvc) GetLogs(context.Context, string, string, string, godo.AppLogType, bool, int) (*godo.AppLogs, *godo.Response, error) {
+	return &godo.AppLogs{HistoricURLs: []string{m.logURL}}, nil, nil
+}
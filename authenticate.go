@@ -1,7 +1,15 @@
 package dog
 
 import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
 	"github.com/digitalocean/godo"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"golang.org/x/oauth2"
 )
 
@@ -13,6 +21,54 @@ type Credentials struct {
 	AccesToken string
 }
 
+// SpacesCredentials are the access key pair Spaces uses in place of the PAT.
+// Endpoint defaults to the DigitalOcean endpoint for the region when empty.
+type SpacesCredentials struct {
+	AccessKey string
+	SecretKey string
+	Endpoint  string
+	Insecure  bool
+}
+
+// Option configures the godo client built by Authenticate.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	baseURL   *url.URL
+	transport http.RoundTripper
+	dryRun    *dryRun
+	logger    *slog.Logger
+	telemetry *telemetry
+}
+
+func newClientOptions(opts []Option) *clientOptions {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithBaseURL points the client at baseURL instead of the DigitalOcean API,
+// e.g. at a dogtest server.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(o *clientOptions) {
+		u := *baseURL
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		o.baseURL = &u
+	}
+}
+
+// WithTransport sends requests through transport, e.g. a dogtest cassette,
+// after the token has been added to them.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
 func (c *Credentials) Token() (*oauth2.Token, error) {
 	token := &oauth2.Token{
 		AccessToken: c.AccesToken,
@@ -20,14 +76,39 @@ func (c *Credentials) Token() (*oauth2.Token, error) {
 	return token, nil
 }
 
-func Authenticate(pat string) *godo.Client {
+func Authenticate(pat string, opts ...Option) *godo.Client {
+
+	options := newClientOptions(opts)
 
 	tokenSource := &Credentials{
 		AccesToken: pat,
 	}
 
-	oauthClient := oauth2.NewClient(oauth2.NoContext, tokenSource)
+	ctx := oauth2.NoContext
+	if options.transport != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: options.transport})
+	}
+
+	oauthClient := oauth2.NewClient(ctx, tokenSource)
 	client := godo.NewClient(oauthClient)
 
+	if options.baseURL != nil {
+		client.BaseURL = options.baseURL
+	}
+
 	return client
 }
+
+func AuthenticateSpaces(sc SpacesCredentials, region Region) (*minio.Client, error) {
+
+	endpoint := sc.Endpoint
+	if endpoint == "" {
+		endpoint = region.String() + ".digitaloceanspaces.com"
+	}
+
+	return minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(sc.AccessKey, sc.SecretKey, ""),
+		Secure: !sc.Insecure,
+		Region: region.String(),
+	})
+}
@@ -0,0 +1,167 @@
+package dog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateCDNRequest struct {
+	Origin        string
+	TTL           uint32
+	CustomDomain  string
+	CertificateID string
+}
+
+type FindAllCDNsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type UpdateCDNTTLRequest struct {
+	ID  string
+	TTL uint32
+}
+
+type UpdateCDNCustomDomainRequest struct {
+	ID            string
+	CustomDomain  string
+	CertificateID string
+}
+
+type PurgeCacheRequest struct {
+	ID    string
+	Paths []string
+}
+
+type DeleteCDNRequest struct {
+	ID string
+}
+
+type CDNClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.CDN, *godo.Response, error)
+	Get(context.Context, string) (*godo.CDN, *godo.Response, error)
+	Create(context.Context, *godo.CDNCreateRequest) (*godo.CDN, *godo.Response, error)
+	UpdateTTL(context.Context, string, *godo.CDNUpdateTTLRequest) (*godo.CDN, *godo.Response, error)
+	UpdateCustomDomain(context.Context, string, *godo.CDNUpdateCustomDomainRequest) (*godo.CDN, *godo.Response, error)
+	FlushCache(context.Context, string, *godo.CDNFlushCacheRequest) (*godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
+type CDN struct {
+	client CDNClient
+}
+
+func NewCDNC(pat string) CDN {
+	client := Authenticate(pat)
+	return CDN{client: client.CDNs}
+}
+
+// SpacesOrigin is the origin hostname of a Spaces bucket in region.
+func SpacesOrigin(bucket string, region Region) string {
+	return bucket + "." + region.String() + ".digitaloceanspaces.com"
+}
+
+func (c *CDN) GetAllCDNs(facr FindAllCDNsRequest) ([]godo.CDN, error) {
+
+	opt := &godo.ListOptions{
+		Page:    facr.Page,
+		PerPage: facr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	cdns, _, err := c.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all CDN endpoints. Godo error: " + err.Error())
+	}
+
+	return cdns, nil
+}
+
+func (c *CDN) GetCDN(id string) (*godo.CDN, error) {
+
+	ctx := context.TODO()
+
+	cdn, _, err := c.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("CDN endpoint with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return cdn, nil
+}
+
+func (c *CDN) CreateCDN(ccr CreateCDNRequest) (*godo.CDN, error) {
+
+	create := &godo.CDNCreateRequest{
+		Origin:        ccr.Origin,
+		TTL:           ccr.TTL,
+		CustomDomain:  ccr.CustomDomain,
+		CertificateID: ccr.CertificateID,
+	}
+
+	ctx := context.TODO()
+
+	cdn, _, err := c.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create CDN endpoint. Godo error: " + err.Error())
+	}
+
+	return cdn, nil
+}
+
+func (c *CDN) UpdateTTL(uctr UpdateCDNTTLRequest) (*godo.CDN, error) {
+
+	ctx := context.TODO()
+
+	cdn, _, err := c.client.UpdateTTL(ctx, uctr.ID, &godo.CDNUpdateTTLRequest{TTL: uctr.TTL})
+	if err != nil {
+		return nil, errors.New("Unable to update TTL of CDN endpoint with id: " + uctr.ID + ". Godo error: " + err.Error())
+	}
+
+	return cdn, nil
+}
+
+// UpdateCustomDomain sets the custom domain served by the endpoint; an empty
+// CustomDomain removes it.
+func (c *CDN) UpdateCustomDomain(uccdr UpdateCDNCustomDomainRequest) (*godo.CDN, error) {
+
+	update := &godo.CDNUpdateCustomDomainRequest{
+		CustomDomain:  uccdr.CustomDomain,
+		CertificateID: uccdr.CertificateID,
+	}
+
+	ctx := context.TODO()
+
+	cdn, _, err := c.client.UpdateCustomDomain(ctx, uccdr.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update custom domain of CDN endpoint with id: " + uccdr.ID + ". Godo error: " + err.Error())
+	}
+
+	return cdn, nil
+}
+
+// PurgeCache flushes cached paths, where "*" flushes everything and
+// "path/*" flushes everything under path.
+func (c *CDN) PurgeCache(pcr PurgeCacheRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := c.client.FlushCache(ctx, pcr.ID, &godo.CDNFlushCacheRequest{Files: pcr.Paths})
+	if err != nil {
+		return errors.New("Unable to purge cache of CDN endpoint with id: " + pcr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (c *CDN) DeleteCDN(dcr DeleteCDNRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := c.client.Delete(ctx, dcr.ID)
+	if err != nil {
+		return errors.New("Unable to delete CDN endpoint with id: " + dcr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestCDN = godo.CDN{
+	ID:       "19f06b6a-3ace-4315-b086-499a0e521b76",
+	Origin:   "dog-artifacts.nyc3.digitaloceanspaces.com",
+	Endpoint: "dog-artifacts.nyc3.cdn.digitaloceanspaces.com",
+	TTL:      3600,
+}
+
+var TestCDNs = []godo.CDN{TestCDN}
+
+func TestSpacesOrigin(t *testing.T) {
+
+	expected := "dog-artifacts.nyc3.digitaloceanspaces.com"
+	returned := SpacesOrigin("dog-artifacts", NYC3)
+	if expected != returned {
+		t.Errorf("expected %s\n , returned, %s\n ", expected, returned)
+	}
+
+}
+
+func TestGetAllCDNs(t *testing.T) {
+
+	cdnClient := NewCDNC(TestPAT)
+	cdnClient.client = &MockGodoCDNSvc{}
+
+	expected := TestCDNs
+	returned, _ := cdnClient.GetAllCDNs(FindAllCDNsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateCDN(t *testing.T) {
+
+	cdnClient := NewCDNC(TestPAT)
+	cdnClient.client = &MockGodoCDNSvc{}
+
+	expected := &TestCDN
+	returned, _ := cdnClient.CreateCDN(CreateCDNRequest{Origin: SpacesOrigin("dog-artifacts", NYC3), TTL: 3600})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestPurgeCache(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		cdnClient := NewCDNC(TestPAT)
+		cdnClient.client = &MockGodoCDNSvc{}
+
+		expectedError := "Unable to purge cache of CDN endpoint with id: " + TestCDN.ID + ". Godo error: " + TestError
+		returnedError := cdnClient.PurgeCache(PurgeCacheRequest{ID: TestCDN.ID, Paths: []string{"assets/*"}})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoCDNSvc struct{}
+
+func (m *MockGodoCDNSvc) List(context.Context, *godo.ListOptions) ([]godo.CDN, *godo.Response, error) {
+	return TestCDNs, nil, nil
+}
+
+func (m *MockGodoCDNSvc) Get(context.Context, string) (*godo.CDN, *godo.Response, error) {
+	return &TestCDN, nil, nil
+}
+
+func (m *MockGodoCDNSvc) Create(context.Context, *godo.CDNCreateRequest) (*godo.CDN, *godo.Response, error) {
+	return &TestCDN, nil, nil
+}
+
+func (m *MockGodoCDNSvc) UpdateTTL(context.Context, string, *godo.CDNUpdateTTLRequest) (*godo.CDN, *godo.Response, error) {
+	return &TestCDN, nil, nil
+}
+
+func (m *MockGodoCDNSvc) UpdateCustomDomain(context.Context, string, *godo.CDNUpdateCustomDomainRequest) (*godo.CDN, *godo.Response, error) {
+	return &TestCDN, nil, nil
+}
+
+func (m *MockGodoCDNSvc) FlushCache(context.Context, string, *godo.CDNFlushCacheRequest) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoCDNSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
@@ -0,0 +1,196 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// CreateCertificateRequest needs DNSNames for a Let's Encrypt certificate and
+// the key and certificates for a custom one.
+type CreateCertificateRequest struct {
+	Name string
+	CertificateKind
+	DNSNames         []string
+	PrivateKey       string
+	LeafCertificate  string
+	CertificateChain string
+}
+
+type FindAllCertificatesRequest struct {
+	Page    int
+	PerPage int
+}
+
+type DeleteCertificateRequest struct {
+	ID string
+}
+
+// ExpiringCertificatesRequest matches certificates expiring within Within of
+// now, including ones already expired. A zero Within matches every certificate.
+type ExpiringCertificatesRequest struct {
+	Within time.Duration
+}
+
+type CertificateExpiry struct {
+	Certificate godo.Certificate
+	NotAfter    time.Time
+	Remaining   time.Duration
+	Expired     bool
+}
+
+// Certificate kinds
+type CertificateKind int
+
+const (
+	LetsEncrypt CertificateKind = iota
+	CustomCertificate
+)
+
+func (ck CertificateKind) String() string {
+	names := [...]string{
+		"lets_encrypt",
+		"custom",
+	}
+	if ck < LetsEncrypt || ck > CustomCertificate {
+		return "That is not a certificate kind"
+	}
+	return names[ck]
+}
+
+type CertificateClient interface {
+	Get(context.Context, string) (*godo.Certificate, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]godo.Certificate, *godo.Response, error)
+	Create(context.Context, *godo.CertificateRequest) (*godo.Certificate, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
+type Certificate struct {
+	client CertificateClient
+	now    func() time.Time
+}
+
+func NewCertC(pat string) Certificate {
+	client := Authenticate(pat)
+	return Certificate{client: client.Certificates, now: time.Now}
+}
+
+func (c *Certificate) GetAllCertificates(facr FindAllCertificatesRequest) ([]godo.Certificate, error) {
+
+	opt := &godo.ListOptions{
+		Page:    facr.Page,
+		PerPage: facr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	certificates, _, err := c.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all certificates. Godo error: " + err.Error())
+	}
+
+	return certificates, nil
+}
+
+func (c *Certificate) GetCertificate(id string) (*godo.Certificate, error) {
+
+	ctx := context.TODO()
+
+	certificate, _, err := c.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("Certificate with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return certificate, nil
+}
+
+func (c *Certificate) CreateCertificate(ccr CreateCertificateRequest) (*godo.Certificate, error) {
+
+	create := &godo.CertificateRequest{
+		Name: ccr.Name,
+		Type: ccr.CertificateKind.String(),
+	}
+	if ccr.CertificateKind == LetsEncrypt {
+		create.DNSNames = ccr.DNSNames
+	} else {
+		create.PrivateKey = ccr.PrivateKey
+		create.LeafCertificate = ccr.LeafCertificate
+		create.CertificateChain = ccr.CertificateChain
+	}
+
+	ctx := context.TODO()
+
+	certificate, _, err := c.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create certificate. Godo error: " + err.Error())
+	}
+
+	return certificate, nil
+}
+
+func (c *Certificate) DeleteCertificate(dcr DeleteCertificateRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := c.client.Delete(ctx, dcr.ID)
+	if err != nil {
+		return errors.New("Unable to delete certificate with id: " + dcr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+// GetExpiringCertificates checks every certificate on the account and returns
+// those expiring soonest first. Certificates not yet issued are left out.
+func (c *Certificate) GetExpiringCertificates(ecr ExpiringCertificatesRequest) ([]CertificateExpiry, error) {
+
+	ctx := context.TODO()
+
+	now := c.now()
+
+	var expiring []CertificateExpiry
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		certificates, resp, err := c.client.List(ctx, opt)
+		if err != nil {
+			return nil, errors.New("Unable to get all certificates. Godo error: " + err.Error())
+		}
+
+		for _, certificate := range certificates {
+			// pending Let's Encrypt certificates have not been issued yet
+			if certificate.NotAfter == "" {
+				continue
+			}
+
+			notAfter, err := time.Parse(time.RFC3339, certificate.NotAfter)
+			if err != nil {
+				return nil, errors.New("Certificate with id: " + certificate.ID + " has an unreadable expiry: " + certificate.NotAfter)
+			}
+
+			remaining := notAfter.Sub(now)
+			if ecr.Within > 0 && remaining > ecr.Within {
+				continue
+			}
+
+			expiring = append(expiring, CertificateExpiry{
+				Certificate: certificate,
+				NotAfter:    notAfter,
+				Remaining:   remaining,
+				Expired:     remaining <= 0,
+			})
+		}
+
+		if isLastPage(resp) {
+			break
+		}
+		opt.Page++
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+	})
+
+	return expiring, nil
+}
@@ -0,0 +1,123 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestNow = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+var TestCertificate = godo.Certificate{
+	ID:       "892071a0-bb95-49bc-8021-3afd67a210bf",
+	Name:     "web-cert",
+	DNSNames: []string{"www.example.com"},
+	NotAfter: "2020-06-15T00:00:00Z",
+	Type:     "lets_encrypt",
+}
+
+var TestExpiredCertificate = godo.Certificate{
+	ID:       "ba9b9c18-6c59-46c2-99df-70da170a42ba",
+	Name:     "old-cert",
+	NotAfter: "2020-05-01T00:00:00Z",
+	Type:     "custom",
+}
+
+var TestLongLivedCertificate = godo.Certificate{
+	ID:       "c7bb7b9d-3a56-4a31-94b2-56a1e1ef5a7c",
+	Name:     "api-cert",
+	NotAfter: "2021-01-01T00:00:00Z",
+	Type:     "lets_encrypt",
+}
+
+var TestPendingCertificate = godo.Certificate{
+	ID:       "d5c2f5a4-8a0e-4b53-9d0f-6b7f3e0c1a2b",
+	Name:     "new-cert",
+	DNSNames: []string{"new.example.com"},
+	State:    "pending",
+	Type:     "lets_encrypt",
+}
+
+var TestCertificates = []godo.Certificate{TestCertificate, TestExpiredCertificate, TestPendingCertificate, TestLongLivedCertificate}
+
+func newTestCertificate() Certificate {
+	certClient := NewCertC(TestPAT)
+	certClient.client = &MockGodoCertificateSvc{}
+	certClient.now = func() time.Time { return TestNow }
+	return certClient
+}
+
+func TestCreateCertificate(t *testing.T) {
+
+	certClient := newTestCertificate()
+
+	expected := &TestCertificate
+	returned, _ := certClient.CreateCertificate(CreateCertificateRequest{Name: "web-cert", CertificateKind: LetsEncrypt, DNSNames: []string{"www.example.com"}})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteCertificate(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		certClient := newTestCertificate()
+
+		expectedError := "Unable to delete certificate with id: " + TestCertificate.ID + ". Godo error: " + TestError
+		returnedError := certClient.DeleteCertificate(DeleteCertificateRequest{ID: TestCertificate.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestGetExpiringCertificates(t *testing.T) {
+
+	certClient := newTestCertificate()
+
+	expected := []CertificateExpiry{
+		{
+			Certificate: TestExpiredCertificate,
+			NotAfter:    time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			Remaining:   -31 * 24 * time.Hour,
+			Expired:     true,
+		},
+		{
+			Certificate: TestCertificate,
+			NotAfter:    time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC),
+			Remaining:   14 * 24 * time.Hour,
+		},
+	}
+	returned, err := certClient.GetExpiringCertificates(ExpiringCertificatesRequest{Within: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+type MockGodoCertificateSvc struct{}
+
+func (m *MockGodoCertificateSvc) Get(context.Context, string) (*godo.Certificate, *godo.Response, error) {
+	return &TestCertificate, nil, nil
+}
+
+func (m *MockGodoCertificateSvc) List(context.Context, *godo.ListOptions) ([]godo.Certificate, *godo.Response, error) {
+	return TestCertificates, nil, nil
+}
+
+func (m *MockGodoCertificateSvc) Create(context.Context, *godo.CertificateRequest) (*godo.Certificate, *godo.Response, error) {
+	return &TestCertificate, nil, nil
+}
+
+func (m *MockGodoCertificateSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
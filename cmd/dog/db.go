@@ -0,0 +1,306 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+	"github.com/spf13/cobra"
+)
+
+func newDatabaseCommand(cfg *config) *cobra.Command {
+
+	db := &cobra.Command{
+		Use:     "db",
+		Aliases: []string{"database", "databases"},
+		Short:   "Manage database clusters",
+	}
+
+	db.AddCommand(
+		newDatabaseListCommand(cfg),
+		newDatabaseGetCommand(cfg),
+		newDatabaseCreateCommand(cfg),
+		newDatabaseDeleteCommand(cfg),
+		newDatabaseResizeCommand(cfg),
+		newDatabaseMigrateCommand(cfg),
+		newDatabaseMaintenanceCommand(cfg),
+		newDatabaseDBsCommand(cfg),
+	)
+	return db
+}
+
+func newDatabaseListCommand(cfg *config) *cobra.Command {
+
+	var page, perPage int
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List database clusters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+
+			clusters, err := db.GetAll(page, perPage)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, clusters, clusterTable(clusters...))
+		},
+	}
+
+	pageFlags(list, &page, &perPage)
+	return list
+}
+
+func newDatabaseGetCommand(cfg *config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a database cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+
+			cluster, err := db.GetById(args[0])
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, cluster, clusterTable(*cluster))
+		},
+	}
+}
+
+func newDatabaseCreateCommand(cfg *config) *cobra.Command {
+
+	var request dog.CreateDatabaseClusterRequest
+	var engine, size, region string
+
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a database cluster",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if request.DatabaseType, err = dog.ParseDatabaseType(engine); err != nil {
+				return err
+			}
+			if request.DatabaseSize, err = dog.ParseDatabaseSize(size); err != nil {
+				return err
+			}
+			if request.Region, err = dog.ParseRegion(region); err != nil {
+				return err
+			}
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+
+			cluster, err := db.Create(request)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, cluster, clusterTable(*cluster))
+		},
+	}
+
+	flags := create.Flags()
+	flags.StringVar(&request.Name, "name", "", "cluster name")
+	flags.StringVar(&engine, "engine", "", "database engine: pg, redis or mysql")
+	flags.StringVar(&request.Version, "version", "", "engine version, defaults to the latest")
+	flags.StringVar(&size, "size", "", "size slug, e.g. db-s-1vcpu-1gb")
+	flags.StringVar(&region, "region", "", "region slug, e.g. nyc3")
+	flags.IntVar(&request.NumNodes, "nodes", 1, "number of nodes")
+	flags.StringSliceVar(&request.Tags, "tag", nil, "tag to apply, repeatable")
+	flags.StringVar(&request.VPC, "vpc", "", "name or UUID of a VPC in the region")
+	flags.StringVar(&request.Project, "project", "", "name or ID of the project to assign the cluster to")
+
+	for _, name := range []string{"name", "engine", "size", "region"} {
+		create.MarkFlagRequired(name)
+	}
+	create.RegisterFlagCompletionFunc("engine", fixed(engines))
+	create.RegisterFlagCompletionFunc("size", fixed(databaseSizes))
+	create.RegisterFlagCompletionFunc("region", fixed(regions))
+	return create
+}
+
+func newDatabaseDeleteCommand(cfg *config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a database cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+			return db.DeleteCluster(args[0])
+		},
+	}
+}
+
+func newDatabaseResizeCommand(cfg *config) *cobra.Command {
+
+	var size string
+	var nodes int
+
+	resize := &cobra.Command{
+		Use:   "resize ID",
+		Short: "Change a database cluster's size and number of nodes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			databaseSize, err := dog.ParseDatabaseSize(size)
+			if err != nil {
+				return err
+			}
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+			return db.ResizeCluster(dog.ResizeClusterRequest{Id: args[0], DatabaseSize: databaseSize, NumNodes: nodes})
+		},
+	}
+
+	resize.Flags().StringVar(&size, "size", "", "size slug, e.g. db-s-2vcpu-4gb")
+	resize.Flags().IntVar(&nodes, "nodes", 1, "number of nodes")
+	resize.MarkFlagRequired("size")
+	resize.RegisterFlagCompletionFunc("size", fixed(databaseSizes))
+	return resize
+}
+
+func newDatabaseMigrateCommand(cfg *config) *cobra.Command {
+
+	var region string
+
+	migrate := &cobra.Command{
+		Use:   "migrate ID",
+		Short: "Move a database cluster to another region",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := dog.ParseRegion(region)
+			if err != nil {
+				return err
+			}
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+			return db.MigrateToNewRegion(dog.MigrateRegionRequest{Id: args[0], Region: r})
+		},
+	}
+
+	migrate.Flags().StringVar(&region, "region", "", "region slug, e.g. ams3")
+	migrate.MarkFlagRequired("region")
+	migrate.RegisterFlagCompletionFunc("region", fixed(regions))
+	return migrate
+}
+
+func newDatabaseMaintenanceCommand(cfg *config) *cobra.Command {
+
+	var request dog.UpdateMaintenanceWindowRequest
+
+	maintenance := &cobra.Command{
+		Use:   "maintenance ID",
+		Short: "Set a database cluster's weekly maintenance window",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+			request.Id = args[0]
+			return db.ConfigureMaintenanceWindow(request)
+		},
+	}
+
+	maintenance.Flags().StringVar(&request.Day, "day", "", "day of the week, e.g. tuesday")
+	maintenance.Flags().StringVar(&request.Time, "hour", "", "start time in UTC, e.g. 14:00")
+	maintenance.MarkFlagRequired("day")
+	maintenance.MarkFlagRequired("hour")
+	maintenance.RegisterFlagCompletionFunc("day", fixed(weekdays))
+	return maintenance
+}
+
+func newDatabaseDBsCommand(cfg *config) *cobra.Command {
+
+	dbs := &cobra.Command{
+		Use:   "dbs",
+		Short: "Manage the databases in a cluster",
+	}
+
+	list := &cobra.Command{
+		Use:   "list CLUSTER_ID",
+		Short: "List the databases in a cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+
+			databases, err := db.FindAllDatabasesInCluster(args[0])
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, databases, databaseTable(databases...))
+		},
+	}
+
+	create := &cobra.Command{
+		Use:   "create CLUSTER_ID NAME",
+		Short: "Add a database to a cluster",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+
+			database, err := db.AddDatabaseToCluster(dog.CreateDatabaseRequest{ClusterID: args[0], Name: args[1]})
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, database, databaseTable(*database))
+		},
+	}
+
+	remove := &cobra.Command{
+		Use:   "delete CLUSTER_ID NAME",
+		Short: "Delete a database from a cluster",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cfg.databases()
+			if err != nil {
+				return err
+			}
+			return db.DeleteDatabaseInCluster(dog.DeleteDatabaseRequest{ClusterID: args[0], Name: args[1]})
+		},
+	}
+
+	dbs.AddCommand(list, create, remove)
+	return dbs
+}
+
+func clusterTable(clusters ...godo.Database) table {
+	t := table{header: []string{"ID", "NAME", "ENGINE", "VERSION", "REGION", "SIZE", "NODES", "STATUS"}}
+	for _, cluster := range clusters {
+		t.rows = append(t.rows, []string{cluster.ID, cluster.Name, cluster.EngineSlug, cluster.VersionSlug, cluster.RegionSlug, cluster.SizeSlug, strconv.Itoa(cluster.NumNodes), cluster.Status})
+	}
+	return t
+}
+
+func databaseTable(databases ...godo.DatabaseDB) table {
+	t := table{header: []string{"NAME"}}
+	for _, database := range databases {
+		t.rows = append(t.rows, []string{database.Name})
+	}
+	return t
+}
+
+func weekdays() []string {
+	return []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog/dogtest"
+)
+
+func TestDatabaseCommands(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+
+	out, err := run(server, "db", "create", "--name", "orders", "--engine", "pg", "--size", "db-s-1vcpu-1gb", "--region", "nyc3")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(out, "ID ") || !strings.Contains(out, "orders") {
+		t.Errorf("expected a table with the cluster, returned %s", out)
+	}
+	id := server.Databases.Clusters()[0].ID
+
+	if _, err := run(server, "db", "resize", id, "--size", "db-s-2vcpu-4gb", "--nodes", "2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := run(server, "db", "maintenance", id, "--day", "tuesday", "--hour", "14:00"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := run(server, "db", "dbs", "create", id, "reports"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cluster := server.Databases.Clusters()[0]
+	expected := &godo.DatabaseMaintenanceWindow{Day: "tuesday", Hour: "14:00"}
+	if cluster.SizeSlug != "db-s-2vcpu-4gb" || cluster.NumNodes != 2 || !reflect.DeepEqual(cluster.MaintenanceWindow, expected) {
+		t.Errorf("unexpected cluster: %+v", cluster)
+	}
+
+	out, _ = run(server, "db", "dbs", "list", id)
+	if out != "NAME\ndefaultdb\nreports\n" {
+		t.Errorf("expected defaultdb and reports, returned %q", out)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		_, returnedError := run(server, "db", "resize", id, "--size", "s-1vcpu-1gb")
+		if returnedError == nil || !strings.HasPrefix(returnedError.Error(), "s-1vcpu-1gb is not a database size") {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+
+		_, returnedError = run(server, "db", "get", "missing")
+		if returnedError == nil || !strings.HasPrefix(returnedError.Error(), "Database cluster with id: missing not found") {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
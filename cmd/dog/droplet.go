@@ -0,0 +1,331 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+	"github.com/spf13/cobra"
+)
+
+func newDropletCommand(cfg *config) *cobra.Command {
+
+	droplet := &cobra.Command{
+		Use:     "droplet",
+		Aliases: []string{"droplets"},
+		Short:   "Manage droplets",
+	}
+
+	droplet.AddCommand(
+		newDropletListCommand(cfg),
+		newDropletGetCommand(cfg),
+		newDropletCreateCommand(cfg),
+		newDropletDeleteCommand(cfg),
+		newDropletImagesCommand(cfg, "backups", "List a droplet's backups"),
+		newDropletImagesCommand(cfg, "snapshots", "List a droplet's snapshots"),
+		newDropletKernelsCommand(cfg),
+		newDropletNeighborsCommand(cfg),
+		newDropletRestoreCommand(cfg),
+	)
+	return droplet
+}
+
+func newDropletListCommand(cfg *config) *cobra.Command {
+
+	var tag string
+	var page, perPage int
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List droplets, optionally only those with a tag",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			var droplets []godo.Droplet
+			if tag != "" {
+				tagged, err := d.GetDropletsByTag(dog.FindDropletsByTagRequest{Tag: tag, Page: page, PerPage: perPage})
+				if err != nil {
+					return err
+				}
+				droplets = *tagged
+			} else {
+				droplets, err = d.GetAllDroplets(dog.FindAllDropletsRequest{Page: page, PerPage: perPage})
+				if err != nil {
+					return err
+				}
+			}
+			return write(cmd.OutOrStdout(), cfg.output, droplets, dropletTable(droplets...))
+		},
+	}
+
+	list.Flags().StringVar(&tag, "tag", "", "only list droplets with this tag")
+	pageFlags(list, &page, &perPage)
+	return list
+}
+
+func newDropletGetCommand(cfg *config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a droplet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			droplet, err := d.GetDropletById(dog.FindDropletByIDRequest{ID: id})
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, droplet, dropletTable(*droplet))
+		},
+	}
+}
+
+func newDropletCreateCommand(cfg *config) *cobra.Command {
+
+	var request dog.CreateDropletRequest
+	var region, size string
+
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a droplet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if request.Region, err = dog.ParseRegion(region); err != nil {
+				return err
+			}
+			if request.DropletSize, err = dog.ParseDropletSize(size); err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			droplet, err := d.CreateDroplet(request)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, droplet, dropletTable(*droplet))
+		},
+	}
+
+	flags := create.Flags()
+	flags.StringVar(&request.Name, "name", "", "droplet name")
+	flags.StringVar(&region, "region", "", "region slug, e.g. nyc3")
+	flags.StringVar(&size, "size", "", "size slug, e.g. s-1vcpu-1gb")
+	flags.StringVar(&request.Image, "image", "", "image slug, e.g. ubuntu-22-04-x64")
+	flags.IntSliceVar(&request.SSHKeys, "ssh-key", nil, "ID of an SSH key to add, repeatable")
+	flags.BoolVar(&request.Backups, "backups", false, "enable weekly backups")
+	flags.BoolVar(&request.IPv6, "ipv6", false, "enable IPv6")
+	flags.BoolVar(&request.Monitoring, "monitoring", false, "install the monitoring agent")
+	flags.BoolVar(&request.PrivateNetworking, "private-networking", false, "enable private networking")
+	flags.StringVar(&request.Configuration, "user-data", "", "cloud-init user data")
+	flags.StringSliceVar(&request.Volumes, "volume", nil, "ID of a volume to attach, repeatable")
+	flags.StringSliceVar(&request.Tags, "tag", nil, "tag to apply, repeatable")
+	flags.StringVar(&request.VPC, "vpc", "", "name or UUID of a VPC in the region")
+	flags.StringVar(&request.Project, "project", "", "name or ID of the project to assign the droplet to")
+
+	for _, name := range []string{"name", "region", "size", "image"} {
+		create.MarkFlagRequired(name)
+	}
+	create.RegisterFlagCompletionFunc("region", fixed(regions))
+	create.RegisterFlagCompletionFunc("size", fixed(dropletSizes))
+	return create
+}
+
+func newDropletDeleteCommand(cfg *config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a droplet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+			return d.DeleteDroplet(dog.DeleteDropletRequest{ID: id})
+		},
+	}
+}
+
+// newDropletImagesCommand lists a droplet's backups or snapshots.
+func newDropletImagesCommand(cfg *config, use string, short string) *cobra.Command {
+
+	var page, perPage int
+
+	images := &cobra.Command{
+		Use:   use + " ID",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			var images []godo.Image
+			if use == "backups" {
+				images, err = d.ListBackups(dog.FindDropletBackupsRequest{ID: id, Page: page, PerPage: perPage})
+			} else {
+				images, err = d.ListSnapshots(dog.FindDropletSnapshotsRequest{ID: id, Page: page, PerPage: perPage})
+			}
+			if err != nil {
+				return err
+			}
+
+			t := table{header: []string{"ID", "NAME", "TYPE", "SIZE GB", "CREATED"}}
+			for _, image := range images {
+				t.rows = append(t.rows, []string{strconv.Itoa(image.ID), image.Name, image.Type, strconv.FormatFloat(image.SizeGigaBytes, 'f', -1, 64), image.Created})
+			}
+			return write(cmd.OutOrStdout(), cfg.output, images, t)
+		},
+	}
+
+	pageFlags(images, &page, &perPage)
+	return images
+}
+
+func newDropletKernelsCommand(cfg *config) *cobra.Command {
+
+	var page, perPage int
+
+	kernels := &cobra.Command{
+		Use:   "kernels ID",
+		Short: "List the kernels available to a droplet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			kernels, err := d.ListKernels(dog.FindDropletKernelsRequest{ID: id, Page: page, PerPage: perPage})
+			if err != nil {
+				return err
+			}
+
+			t := table{header: []string{"ID", "NAME", "VERSION"}}
+			for _, kernel := range kernels {
+				t.rows = append(t.rows, []string{strconv.Itoa(kernel.ID), kernel.Name, kernel.Version})
+			}
+			return write(cmd.OutOrStdout(), cfg.output, kernels, t)
+		},
+	}
+
+	pageFlags(kernels, &page, &perPage)
+	return kernels
+}
+
+func newDropletNeighborsCommand(cfg *config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "neighbors ID",
+		Short: "List the droplets sharing a droplet's host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			neighbors, err := d.ListNeighbors(id)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), cfg.output, neighbors, dropletTable(neighbors...))
+		},
+	}
+}
+
+func newDropletRestoreCommand(cfg *config) *cobra.Command {
+
+	var backupID int
+
+	restore := &cobra.Command{
+		Use:   "restore ID",
+		Short: "Restore a droplet from one of its backups",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := dropletID(args[0])
+			if err != nil {
+				return err
+			}
+			d, err := cfg.droplets()
+			if err != nil {
+				return err
+			}
+
+			action, err := d.RestoreFromBackup(dog.RestoreDropletRequest{ID: id, BackupID: backupID})
+			if err != nil {
+				return err
+			}
+
+			t := table{header: []string{"ID", "TYPE", "STATUS", "STARTED"}}
+			started := ""
+			if action.StartedAt != nil {
+				started = action.StartedAt.String()
+			}
+			t.rows = append(t.rows, []string{strconv.Itoa(action.ID), action.Type, action.Status, started})
+			return write(cmd.OutOrStdout(), cfg.output, action, t)
+		},
+	}
+
+	restore.Flags().IntVar(&backupID, "backup", 0, "ID of the backup image to restore")
+	restore.MarkFlagRequired("backup")
+	return restore
+}
+
+func dropletTable(droplets ...godo.Droplet) table {
+	t := table{header: []string{"ID", "NAME", "STATUS", "REGION", "SIZE", "PUBLIC IPV4", "TAGS"}}
+	for _, droplet := range droplets {
+		region := ""
+		if droplet.Region != nil {
+			region = droplet.Region.Slug
+		}
+		ip, _ := droplet.PublicIPv4()
+		t.rows = append(t.rows, []string{strconv.Itoa(droplet.ID), droplet.Name, droplet.Status, region, droplet.SizeSlug, ip, strings.Join(droplet.Tags, ",")})
+	}
+	return t
+}
+
+func dropletID(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, errors.New("Droplet ID " + arg + " is not a number")
+	}
+	return id, nil
+}
+
+func pageFlags(cmd *cobra.Command, page *int, perPage *int) {
+	cmd.Flags().IntVar(page, "page", 1, "page of results")
+	cmd.Flags().IntVar(perPage, "per-page", 20, "results per page, at most 200")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog/dogtest"
+)
+
+func TestDropletCommands(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+	server.Droplets.AddDroplet(godo.Droplet{Name: "db", Tags: []string{"db"}})
+
+	out, err := run(server, "droplet", "create", "--name", "web", "--region", "nyc3", "--size", "s-1vcpu-1gb", "--image", "ubuntu-22-04-x64", "--tag", "web", "-o", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var created godo.Droplet
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatalf("unexpected output %s: %s", out, err)
+	}
+	if created.Name != "web" || created.SizeSlug != "s-1vcpu-1gb" || created.Region.Slug != "nyc3" {
+		t.Errorf("unexpected droplet: %+v", created)
+	}
+
+	out, _ = run(server, "droplet", "list", "--tag", "web")
+	if !strings.Contains(out, "web") || strings.Contains(out, "db ") {
+		t.Errorf("expected only the web droplet, returned %s", out)
+	}
+
+	out, _ = run(server, "droplet", "get", "2", "-o", "yaml")
+	if !strings.Contains(out, "name: web\n") {
+		t.Errorf("expected the web droplet as yaml, returned %s", out)
+	}
+
+	if _, err := run(server, "droplet", "delete", "2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if droplets := server.Droplets.Droplets(); len(droplets) != 1 {
+		t.Errorf("expected the web droplet to be deleted, returned %+v", droplets)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		_, returnedError := run(server, "droplet", "create", "--name", "web", "--region", "mars1", "--size", "s-1vcpu-1gb", "--image", "ubuntu-22-04-x64")
+		if returnedError == nil || !strings.HasPrefix(returnedError.Error(), "mars1 is not a region") {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+
+		_, returnedError = run(server, "droplet", "get", "web")
+		if returnedError == nil || returnedError.Error() != "Droplet ID web is not a number" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
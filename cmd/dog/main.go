@@ -0,0 +1,157 @@
+// Command dog manages DigitalOcean droplets and database clusters.
+//
+//	dog droplet create --name web --region nyc3 --size s-1vcpu-1gb --image ubuntu-22-04-x64
+//	dog db resize 9cc10173-e9ea-4176-9dbc-a4cee4c4ff30 --size db-s-2vcpu-4gb --nodes 2
+//	dog apply -f infrastructure.yaml
+//	dog --dry-run db migrate 9cc10173-e9ea-4176-9dbc-a4cee4c4ff30 --region ams3
+//
+// The token is read from --token or DIGITALOCEAN_TOKEN.
+package main
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+
+	"github.com/evancaplan/dog"
+	"github.com/spf13/cobra"
+)
+
+const tokenEnv = "DIGITALOCEAN_TOKEN"
+
+type config struct {
+	token   string
+	apiURL  string
+	output  string
+	dryRun  bool
+	verbose bool
+	// log is where dry runs write the requests they would have sent, and
+	// where operations are logged with --verbose
+	log io.Writer
+}
+
+// driftExitCode is the exit code of dog drift when resources have drifted,
+// telling drift apart from failing to check for it.
+const driftExitCode = 2
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		if errors.Is(err, errDrift) {
+			os.Exit(driftExitCode)
+		}
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+
+	cfg := &config{}
+
+	root := &cobra.Command{
+		Use:          "dog",
+		Short:        "Manage DigitalOcean droplets and database clusters",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg.log = cmd.ErrOrStderr()
+			return validateFormat(cfg.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVarP(&cfg.token, "token", "t", "", "API token, defaults to $"+tokenEnv)
+	flags.StringVarP(&cfg.output, "output", "o", tableFormat, "output format: table, json or yaml")
+	flags.StringVar(&cfg.apiURL, "api-url", "", "API base URL, defaults to the DigitalOcean API")
+	flags.BoolVar(&cfg.dryRun, "dry-run", false, "print the requests that would change resources instead of sending them")
+	flags.BoolVarP(&cfg.verbose, "verbose", "v", false, "log each API call to stderr")
+	root.RegisterFlagCompletionFunc("output", fixed(formats))
+
+	root.AddCommand(newDropletCommand(cfg), newDatabaseCommand(cfg), newPlanCommand(cfg), newApplyCommand(cfg), newDriftCommand(cfg), newImportCommand(cfg))
+	return root
+}
+
+// authenticate returns the token and client options for the flags.
+func (c *config) authenticate() (string, []dog.Option, error) {
+
+	token := c.token
+	if token == "" {
+		token = os.Getenv(tokenEnv)
+	}
+	if token == "" {
+		return "", nil, errors.New("No API token. Set --token or " + tokenEnv)
+	}
+
+	var opts []dog.Option
+	if c.apiURL != "" {
+		u, err := url.Parse(c.apiURL)
+		if err != nil {
+			return "", nil, errors.New("Invalid API URL " + c.apiURL + ". " + err.Error())
+		}
+		opts = append(opts, dog.WithBaseURL(u))
+	}
+	if c.dryRun {
+		opts = append(opts, dog.WithDryRun(c.log))
+	}
+	if c.verbose {
+		opts = append(opts, dog.WithLogger(slog.New(slog.NewTextHandler(c.log, nil))))
+	}
+
+	return token, opts, nil
+}
+
+func (c *config) droplets() (dog.Droplet, error) {
+	token, opts, err := c.authenticate()
+	if err != nil {
+		return dog.Droplet{}, err
+	}
+	return dog.NewDC(token, opts...), nil
+}
+
+func (c *config) databases() (dog.Database, error) {
+	token, opts, err := c.authenticate()
+	if err != nil {
+		return dog.Database{}, err
+	}
+	return dog.NewDBC(token, opts...), nil
+}
+
+func (c *config) infrastructure() (dog.Infrastructure, error) {
+	token, opts, err := c.authenticate()
+	if err != nil {
+		return dog.Infrastructure{}, err
+	}
+	return dog.NewIC(token, opts...), nil
+}
+
+// Slugs accepted by flags, completed from the enums.
+
+type enum interface {
+	~int
+	String() string
+}
+
+func slugs[E enum](first E, last E) []string {
+	var names []string
+	for e := first; e <= last; e++ {
+		names = append(names, e.String())
+	}
+	return names
+}
+
+func regions() []string { return slugs(dog.NYC1, dog.BLR1) }
+
+func dropletSizes() []string { return slugs(dog.S1Cpu1GbRAM, dog.S32Cpu19GbRAM) }
+
+func databaseSizes() []string {
+	return slugs(dog.DbS1Cpu1GbRAM10GbStorage, dog.DbS16Cpu64GbRAM1120GbStorage)
+}
+
+func engines() []string { return slugs(dog.PostGres, dog.MySQL) }
+
+// fixed completes a flag from a fixed list of values.
+func fixed(values func() []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
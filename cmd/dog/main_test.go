@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/evancaplan/dog"
+	"github.com/evancaplan/dog/dogtest"
+)
+
+const TestToken = "dog-cli-token"
+
+// run runs dog against server with args and returns what it printed.
+func run(server *dogtest.Server, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	root := newRootCommand()
+	root.SetOut(out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"--token", TestToken, "--api-url", server.URL}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestSlugs(t *testing.T) {
+
+	expected := []string{"pg", "redis", "mysql"}
+	if returned := engines(); !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	if returned := regions(); len(returned) != 12 || returned[2] != dog.NYC3.String() {
+		t.Errorf("unexpected regions: %+v", returned)
+	}
+
+}
+
+func TestCompletion(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+
+	out, err := run(server, "__complete", "droplet", "create", "--region", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, region := range regions() {
+		if !strings.Contains(out, region+"\n") {
+			t.Errorf("expected %s to be completed, returned %s", region, out)
+		}
+	}
+
+	out, _ = run(server, "__complete", "db", "create", "--size", "")
+	if !strings.Contains(out, "db-s-16vcpu-64gb\n") {
+		t.Errorf("expected database sizes to be completed, returned %s", out)
+	}
+
+}
+
+func TestMissingToken(t *testing.T) {
+
+	t.Setenv(tokenEnv, "")
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		root := newRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs([]string{"droplet", "list"})
+		returnedError := root.Execute()
+		if returnedError == nil || returnedError.Error() != "No API token. Set --token or DIGITALOCEAN_TOKEN" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
+
+func TestDryRun(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+
+	out, log := &bytes.Buffer{}, &bytes.Buffer{}
+	root := newRootCommand()
+	root.SetOut(out)
+	root.SetErr(log)
+	root.SetArgs([]string{"--token", TestToken, "--api-url", server.URL, "--dry-run", "db", "create", "--name", "orders", "--engine", "pg", "--size", "db-s-1vcpu-1gb", "--region", "nyc3"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if clusters := server.Databases.Clusters(); len(clusters) != 0 {
+		t.Errorf("expected nothing to be created, returned %+v", clusters)
+	}
+	if !strings.Contains(out.String(), "dry-run-1") {
+		t.Errorf("expected a table with the synthesized cluster, returned %s", out)
+	}
+	if !strings.HasPrefix(log.String(), "dry run: Databases.Create {") || !strings.Contains(log.String(), `"name":"orders"`) {
+		t.Errorf("expected the request to be logged, returned %s", log)
+	}
+
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	tableFormat = "table"
+	jsonFormat  = "json"
+	yamlFormat  = "yaml"
+)
+
+func formats() []string { return []string{tableFormat, jsonFormat, yamlFormat} }
+
+func validateFormat(format string) error {
+	for _, f := range formats() {
+		if f == format {
+			return nil
+		}
+	}
+	return errors.New(format + " is not an output format. Use one of " + strings.Join(formats(), ", "))
+}
+
+// table is how a value is printed in the table format.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// write prints value as JSON or YAML using the API's field names, or as t.
+func write(w io.Writer, format string, value interface{}, t table) error {
+
+	switch format {
+	case jsonFormat:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+
+	case yamlFormat:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		// JSON is YAML, so decoding it to a node keeps the API's field names
+		// and order; clearing the flow style prints it as block YAML
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		blockStyle(&node)
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		io.WriteString(tw, strings.Join(t.header, "\t")+"\n")
+		for _, row := range t.rows {
+			io.WriteString(tw, strings.Join(row, "\t")+"\n")
+		}
+		return tw.Flush()
+	}
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+func TestWrite(t *testing.T) {
+
+	droplet := godo.Droplet{ID: 7, Name: "web", Status: "active", Region: &godo.Region{Slug: "nyc3"}, SizeSlug: "s-1vcpu-1gb", Tags: []string{"web", "prod"}}
+
+	tests := map[string]string{
+		tableFormat: "ID  NAME  STATUS  REGION  SIZE         PUBLIC IPV4  TAGS\n7   web   active  nyc3    s-1vcpu-1gb               web,prod\n",
+		jsonFormat:  "{\n  \"id\": 7,\n  \"name\": \"web\",\n",
+		yamlFormat:  "id: 7\nname: web\nregion:\n  slug: nyc3\n",
+	}
+
+	for format, expected := range tests {
+		out := &bytes.Buffer{}
+		if err := write(out, format, droplet, dropletTable(droplet)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		returned := out.String()
+		if len(returned) < len(expected) || returned[:len(expected)] != expected {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+		}
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		returnedError := validateFormat("xml")
+		if returnedError == nil || returnedError.Error() != "xml is not an output format. Use one of table, json, yaml" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evancaplan/dog"
+	"github.com/spf13/cobra"
+)
+
+// specFlags are the flags shared by plan and apply.
+type specFlags struct {
+	file  string
+	prune bool
+}
+
+func (f *specFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "YAML or JSON spec of the droplets and database clusters")
+	cmd.Flags().BoolVar(&f.prune, "prune", false, "delete droplets and database clusters missing from the spec")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagFilename("file", "yaml", "yml", "json")
+}
+
+// plan loads the spec and plans it against the account.
+func (f *specFlags) plan(cfg *config) (dog.Infrastructure, *dog.Plan, error) {
+
+	spec, err := dog.LoadSpec(f.file)
+	if err != nil {
+		return dog.Infrastructure{}, nil, err
+	}
+	infra, err := cfg.infrastructure()
+	if err != nil {
+		return dog.Infrastructure{}, nil, err
+	}
+
+	plan, err := infra.Plan(dog.PlanRequest{Spec: *spec, Prune: f.prune})
+	return infra, plan, err
+}
+
+func newPlanCommand(cfg *config) *cobra.Command {
+
+	flags := &specFlags{}
+
+	plan := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the changes that would make the account match a spec",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, plan, err := flags.plan(cfg)
+			if err != nil {
+				return err
+			}
+			return writePlan(cmd.OutOrStdout(), cfg.output, plan)
+		},
+	}
+
+	flags.register(plan)
+	return plan
+}
+
+func newApplyCommand(cfg *config) *cobra.Command {
+
+	flags := &specFlags{}
+	var yes bool
+
+	apply := &cobra.Command{
+		Use:   "apply",
+		Short: "Make the account match a spec, after confirming the plan",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			infra, plan, err := flags.plan(cfg)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if err := writePlan(out, cfg.output, plan); err != nil {
+				return err
+			}
+			if len(plan.Changes) == 0 {
+				return nil
+			}
+
+			if !yes {
+				io.WriteString(cmd.ErrOrStderr(), "\nApply "+strconv.Itoa(len(plan.Changes))+" changes? Only yes is accepted: ")
+				answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if strings.TrimSpace(answer) != "yes" {
+					return errors.New("Apply cancelled")
+				}
+			}
+
+			if err := infra.Apply(plan); err != nil {
+				return err
+			}
+			io.WriteString(cmd.ErrOrStderr(), "Applied "+strconv.Itoa(len(plan.Changes))+" changes.\n")
+			return nil
+		},
+	}
+
+	flags.register(apply)
+	apply.Flags().BoolVarP(&yes, "yes", "y", false, "apply without asking for confirmation")
+	return apply
+}
+
+// errDrift is returned by dog drift when resources have drifted.
+var errDrift = errors.New("Resources have drifted from the spec")
+
+func newDriftCommand(cfg *config) *cobra.Command {
+
+	var file string
+
+	drift := &cobra.Command{
+		Use:   "drift",
+		Short: "Report how live resources differ from a spec, exiting 2 if they do",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := dog.LoadSpec(file)
+			if err != nil {
+				return err
+			}
+			infra, err := cfg.infrastructure()
+			if err != nil {
+				return err
+			}
+
+			report, err := infra.DetectDrift(*spec)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if cfg.output == tableFormat {
+				_, err = io.WriteString(out, report.String())
+			} else {
+				err = write(out, cfg.output, report, table{})
+			}
+			if err != nil {
+				return err
+			}
+
+			if report.HasDrift() {
+				return errDrift
+			}
+			return nil
+		},
+	}
+
+	drift.Flags().StringVarP(&file, "file", "f", "", "YAML or JSON spec of the droplets and database clusters")
+	drift.MarkFlagRequired("file")
+	drift.MarkFlagFilename("file", "yaml", "yml", "json")
+	return drift
+}
+
+func newImportCommand(cfg *config) *cobra.Command {
+
+	var file string
+	var force bool
+	var request dog.ImportRequest
+
+	imp := &cobra.Command{
+		Use:   "import",
+		Short: "Write a spec of existing droplets and database clusters",
+		Long: "Write a spec of existing droplets and database clusters, selected by ID,\n" +
+			"or by tag and name pattern. Without selectors every resource is imported.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(file); err == nil && !force {
+				return errors.New(file + " already exists. Use --force to overwrite it")
+			}
+			infra, err := cfg.infrastructure()
+			if err != nil {
+				return err
+			}
+
+			result, err := infra.Import(request)
+			if err != nil {
+				return err
+			}
+			if err := dog.WriteSpec(file, result.Spec); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, skipped := range result.Skipped {
+				resource := skipped.Resource
+				if skipped.Name != "" {
+					resource += " " + skipped.Name
+				}
+				io.WriteString(cmd.ErrOrStderr(), "Skipped "+resource+" ("+skipped.ID+"): "+skipped.Reason+"\n")
+			}
+			io.WriteString(out, "Imported "+strconv.Itoa(len(result.Droplets))+" droplets and "+strconv.Itoa(len(result.Databases))+" database clusters into "+file+".\n")
+			return nil
+		},
+	}
+
+	flags := imp.Flags()
+	flags.StringVarP(&file, "file", "f", "", "spec file to write, as JSON if it ends in .json and YAML otherwise")
+	flags.BoolVar(&force, "force", false, "overwrite the spec file if it exists")
+	flags.StringVar(&request.Tag, "tag", "", "import resources with this tag")
+	flags.StringVar(&request.NamePattern, "name", "", "import resources whose names match this glob, e.g. web-*")
+	flags.IntSliceVar(&request.DropletIDs, "droplet", nil, "ID of a droplet to import, repeatable")
+	flags.StringSliceVar(&request.ClusterIDs, "cluster", nil, "ID of a database cluster to import, repeatable")
+	imp.MarkFlagRequired("file")
+	imp.MarkFlagFilename("file", "yaml", "yml", "json")
+	return imp
+}
+
+// writePlan prints the plan as a diff in the table format.
+func writePlan(w io.Writer, format string, plan *dog.Plan) error {
+	if format == tableFormat {
+		_, err := io.WriteString(w, plan.String())
+		return err
+	}
+	return write(w, format, plan, table{})
+}
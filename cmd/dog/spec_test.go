@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog/dogtest"
+)
+
+const TestSpecFile = `
+droplets:
+  - name: web
+    region: nyc3
+    size: s-1vcpu-1gb
+    image: ubuntu-22-04-x64
+databases:
+  - name: orders
+    engine: pg
+    size: db-s-2vcpu-4gb
+    region: nyc3
+    num_nodes: 2
+    maintenance_window: {day: tuesday, hour: "14:00"}
+`
+
+func writeSpec(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(TestSpecFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPlanAndApply(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+	server.Databases.AddCluster(godo.Database{Name: "orders", EngineSlug: "pg", RegionSlug: "nyc3", SizeSlug: "db-s-1vcpu-1gb", NumNodes: 1, Status: "online"})
+	server.Droplets.AddDroplet(godo.Droplet{Name: "old"})
+	path := writeSpec(t)
+
+	expected := "+ droplet web (nyc3, s-1vcpu-1gb, ubuntu-22-04-x64)\n" +
+		"~ database cluster orders: resize db-s-1vcpu-1gb x1 -> db-s-2vcpu-4gb x2\n" +
+		"~ database cluster orders: maintenance none -> tuesday 14:00\n" +
+		"- droplet old (1)\n" +
+		"\nPlan: 1 to create, 2 to change, 1 to delete.\n"
+	returned, err := run(server, "plan", "-f", path, "--prune")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected != returned {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	returned, _ = run(server, "plan", "-f", path, "-o", "json")
+	if !strings.Contains(returned, `"Kind": "resize"`) {
+		t.Errorf("expected the plan as json, returned %s", returned)
+	}
+
+	if _, err := run(server, "apply", "-f", path, "--prune", "--yes"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	droplets := server.Droplets.Droplets()
+	cluster := server.Databases.Clusters()[0]
+	if len(droplets) != 1 || droplets[0].Name != "web" || cluster.NumNodes != 2 || cluster.MaintenanceWindow.Day != "tuesday" {
+		t.Errorf("unexpected state after apply: %+v %+v", droplets, cluster)
+	}
+
+	returned, _ = run(server, "apply", "-f", path, "--prune")
+	if returned != "No changes.\n" {
+		t.Errorf("expected nothing left to apply, returned %s", returned)
+	}
+
+	returned, err = run(server, "drift", "-f", path)
+	if err != nil || returned != "No drift in 2 resources.\n" {
+		t.Errorf("expected no drift after apply, returned %s %v", returned, err)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		server.Droplets.AddDroplet(godo.Droplet{Name: "old"})
+
+		out, prompt := &strings.Builder{}, &strings.Builder{}
+		root := newRootCommand()
+		root.SetOut(out)
+		root.SetErr(prompt)
+		root.SetIn(strings.NewReader("no\n"))
+		root.SetArgs([]string{"--token", TestToken, "--api-url", server.URL, "apply", "-f", path, "--prune", "-o", "json"})
+		returnedError := root.Execute()
+		if returnedError == nil || returnedError.Error() != "Apply cancelled" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+		var plan map[string]interface{}
+		if err := json.Unmarshal([]byte(out.String()), &plan); err != nil || !strings.Contains(prompt.String(), "Only yes is accepted") {
+			t.Errorf("expected the plan alone on stdout and the prompt on stderr, returned %s %s", out, prompt)
+		}
+		if len(server.Droplets.Droplets()) != 2 {
+			t.Errorf("expected nothing to be deleted when apply is cancelled")
+		}
+
+		server.Databases.Resize(t.Context(), cluster.ID, &godo.DatabaseResizeRequest{SizeSlug: "db-s-1vcpu-1gb", NumNodes: 1})
+		returned, returnedError = run(server, "drift", "-f", path, "-o", "json")
+		if !errors.Is(returnedError, errDrift) || !strings.Contains(returned, `"Drifted": 1`) {
+			t.Errorf("expected the resize to be reported as drift, returned %s %v", returned, returnedError)
+		}
+	})
+
+}
+
+func TestImport(t *testing.T) {
+
+	server := dogtest.NewServer()
+	defer server.Close()
+	server.Droplets.AddDroplet(godo.Droplet{Name: "web-1", Region: &godo.Region{Slug: "nyc3"}, SizeSlug: "s-1vcpu-1gb", Image: &godo.Image{Slug: "ubuntu-22-04-x64"}, Tags: []string{"prod"}})
+	server.Droplets.AddDroplet(godo.Droplet{Name: "web-2", Region: &godo.Region{Slug: "nyc3"}, SizeSlug: "c-2", Image: &godo.Image{Slug: "ubuntu-22-04-x64"}, Tags: []string{"prod"}})
+	server.Droplets.AddDroplet(godo.Droplet{Name: "scratch", Region: &godo.Region{Slug: "nyc3"}, SizeSlug: "s-1vcpu-1gb", Image: &godo.Image{Slug: "ubuntu-22-04-x64"}})
+	server.Databases.AddCluster(godo.Database{Name: "orders", EngineSlug: "pg", VersionSlug: "16", RegionSlug: "nyc3", SizeSlug: "db-s-1vcpu-1gb", NumNodes: 1, DBNames: []string{"defaultdb"}, Tags: []string{"prod"}})
+	path := filepath.Join(t.TempDir(), "imported.yaml")
+
+	returned, err := run(server, "import", "-f", path, "--tag", "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if returned != "Imported 1 droplets and 1 database clusters into "+path+".\n" {
+		t.Errorf("unexpected output: %s", returned)
+	}
+
+	returned, _ = run(server, "plan", "-f", path)
+	if returned != "No changes.\n" {
+		t.Errorf("expected the imported spec to plan no changes, returned %s", returned)
+	}
+	returned, err = run(server, "drift", "-f", path)
+	if err != nil {
+		t.Errorf("expected no drift from the imported spec, returned %s %v", returned, err)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		_, returnedError := run(server, "import", "-f", path)
+		if returnedError == nil || returnedError.Error() != path+" already exists. Use --force to overwrite it" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
@@ -3,6 +3,8 @@ package dog
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"strings"
 
 	"github.com/digitalocean/godo"
 )
@@ -17,6 +19,10 @@ type CreateDatabaseClusterRequest struct {
 	Region
 	NumNodes int
 	Tags     []string
+	// VPC is the name or UUID of a VPC in Region
+	VPC string
+	// Project is the name or ID of the project the cluster is assigned to
+	Project string
 }
 
 type ResizeClusterRequest struct {
@@ -70,6 +76,12 @@ func (dbt DatabaseType) String() string {
 	return names[dbt]
 }
 
+// ParseDatabaseType returns the database type with the engine slug, e.g. "pg".
+func ParseDatabaseType(slug string) (DatabaseType, error) {
+	i, err := parseSlug("database engine", slug, int(MySQL)+1, func(i int) string { return DatabaseType(i).String() })
+	return DatabaseType(i), err
+}
+
 // Droplet regions
 type Region int
 
@@ -109,6 +121,12 @@ func (r Region) String() string {
 	return names[r]
 }
 
+// ParseRegion returns the region with slug, e.g. "nyc3".
+func ParseRegion(slug string) (Region, error) {
+	i, err := parseSlug("region", slug, int(BLR1)+1, func(i int) string { return Region(i).String() })
+	return Region(i), err
+}
+
 // Database Sizes
 type DatabaseSize int
 
@@ -138,10 +156,29 @@ func (ds DatabaseSize) String() string {
 	return names[ds]
 }
 
+// ParseDatabaseSize returns the database size with slug, e.g. "db-s-1vcpu-1gb".
+func ParseDatabaseSize(slug string) (DatabaseSize, error) {
+	i, err := parseSlug("database size", slug, int(DbS16Cpu64GbRAM1120GbStorage)+1, func(i int) string { return DatabaseSize(i).String() })
+	return DatabaseSize(i), err
+}
+
+// parseSlug finds slug among the names of the count values of an enum.
+func parseSlug(kind string, slug string, count int, name func(int) string) (int, error) {
+	var names []string
+	for i := 0; i < count; i++ {
+		if name(i) == slug {
+			return i, nil
+		}
+		names = append(names, name(i))
+	}
+	return 0, errors.New(slug + " is not a " + kind + ". Use one of " + strings.Join(names, ", "))
+}
+
 type DatabaseClient interface {
 	Get(context.Context, string) (*godo.Database, *godo.Response, error)
 	Create(context.Context, *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error)
 	List(context.Context, *godo.ListOptions) ([]godo.Database, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
 	Resize(context.Context, string, *godo.DatabaseResizeRequest) (*godo.Response, error)
 	Migrate(context.Context, string, *godo.DatabaseMigrateRequest) (*godo.Response, error)
 	UpdateMaintenance(context.Context, string, *godo.DatabaseUpdateMaintenanceRequest) (*godo.Response, error)
@@ -152,22 +189,43 @@ type DatabaseClient interface {
 }
 
 type Database struct {
-	client DatabaseClient
+	client   DatabaseClient
+	vpcs     VPCClient
+	projects ProjectClient
+	dryRun   *dryRun
+	observer observer
 }
 
-func NewDBC(pat string) Database {
-	client := Authenticate(pat)
-	return Database{client: client.Databases}
+func NewDBC(pat string, opts ...Option) Database {
+	client := Authenticate(pat, opts...)
+	options := newClientOptions(opts)
+	return Database{client: client.Databases, vpcs: client.VPCs, projects: client.Projects, dryRun: options.dryRun, observer: options.observer()}
+}
+
+// NewDBCWithClient builds a Database on client, e.g. a dogtest fake, instead
+// of the DigitalOcean API. VPC and project lookups are unavailable. Only
+// WithDryRun, WithLogger and WithTelemetry of opts apply.
+func NewDBCWithClient(client DatabaseClient, opts ...Option) Database {
+	options := newClientOptions(opts)
+	return Database{client: client, dryRun: options.dryRun, observer: options.observer()}
 }
 
 func (db *Database) Create(cdcr CreateDatabaseClusterRequest) (*godo.Database, error) {
 
+	// check what the API would otherwise reject
+	if db.dryRun != nil {
+		if err := cdcr.validate(); err != nil {
+			return nil, errors.New("Unable to create database cluster. " + err.Error())
+		}
+	}
+
 	// create new godo DatabaseCreateRequest
 	create := &godo.DatabaseCreateRequest{
 		Name:       cdcr.Name,
 		EngineSlug: cdcr.DatabaseType.String(),
 		Version:    cdcr.Version,
 		SizeSlug:   cdcr.DatabaseSize.String(),
+		Region:     cdcr.Region.String(),
 		NumNodes:   cdcr.NumNodes,
 		Tags:       cdcr.Tags,
 	}
@@ -175,12 +233,52 @@ func (db *Database) Create(cdcr CreateDatabaseClusterRequest) (*godo.Database, e
 	// generate new client and create empty context
 	ctx := context.TODO()
 
+	// place the cluster in the requested VPC
+	if cdcr.VPC != "" {
+		vpc, err := resolveVPC(ctx, db.observer, db.vpcs, cdcr.VPC, cdcr.Region)
+		if err != nil {
+			return nil, errors.New("Unable to create database cluster. " + err.Error())
+		}
+		create.PrivateNetworkUUID = vpc.ID
+	}
+
+	// look the project up before anything is created
+	var project *godo.Project
+	if cdcr.Project != "" {
+		found, err := findProject(ctx, db.observer, db.projects, cdcr.Project)
+		if err != nil {
+			return nil, errors.New("Unable to create database cluster. " + err.Error())
+		}
+		project = found
+	}
+
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		db.dryRun.record("Databases.Create", create)
+		if project != nil {
+			db.dryRun.record("Projects.AssignResources", project.ID)
+		}
+		return db.dryRun.cluster(create), nil
+	}
+
 	// create new database cluster
-	cluster, _, err := db.client.Create(ctx, create)
+	ctx, op := db.observer.begin(ctx, "Databases.Create", slog.String("region", create.Region), slog.String("size", create.SizeSlug), logRequest(create))
+	cluster, resp, err := db.client.Create(ctx, create)
+	if cluster != nil {
+		op.add(slog.String("cluster_id", cluster.ID))
+	}
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to create database cluster. Godo error: " + err.Error())
 	}
 
+	// assign the new cluster, returning it even if assignment fails
+	if project != nil {
+		if _, err := assignToProject(ctx, db.observer, db.projects, project.ID, cluster.URN()); err != nil {
+			return cluster, errors.New("Database cluster with id: " + cluster.ID + " was created but not assigned to project. " + err.Error())
+		}
+	}
+
 	return cluster, nil
 }
 
@@ -190,7 +288,12 @@ func (db *Database) GetById(id string) (*godo.Database, error) {
 	ctx := context.TODO()
 
 	// find database cluster by id
-	cluster, _, err := db.client.Get(ctx, id)
+	ctx, op := db.observer.begin(ctx, "Databases.Get", slog.String("cluster_id", id))
+	cluster, resp, err := db.client.Get(ctx, id)
+	if cluster != nil {
+		op.add(slog.String("region", cluster.RegionSlug), slog.String("size", cluster.SizeSlug))
+	}
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Database cluster with id: " + id + " not found. Godo error: " + err.Error())
 	}
@@ -210,7 +313,9 @@ func (db *Database) GetAll(page int, numberPerPage int) ([]godo.Database, error)
 	ctx := context.TODO()
 
 	// find all database clusters
-	clusters, _, err := db.client.List(ctx, opt)
+	ctx, op := db.observer.begin(ctx, "Databases.List", slog.Int("page", page))
+	clusters, resp, err := db.client.List(ctx, opt)
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to get all database clusters. Godo error: " + err.Error())
 	}
@@ -218,6 +323,31 @@ func (db *Database) GetAll(page int, numberPerPage int) ([]godo.Database, error)
 	return clusters, nil
 }
 
+func (db *Database) DeleteCluster(id string) error {
+
+	// generate empty context
+	ctx := context.TODO()
+
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if id == "" {
+			return errors.New("Unable to delete database cluster. A database cluster ID is required")
+		}
+		db.dryRun.record("Databases.Delete", id)
+		return nil
+	}
+
+	// send delete cluster request
+	ctx, op := db.observer.begin(ctx, "Databases.Delete", slog.String("cluster_id", id))
+	resp, err := db.client.Delete(ctx, id)
+	op.end(resp, err)
+	if err != nil {
+		return errors.New("Unable to delete database cluster " + id + ". Godo error: " + err.Error())
+	}
+
+	return nil
+}
+
 func (db *Database) ResizeCluster(rcr ResizeClusterRequest) error {
 
 	// create new godo ResizeDatabaseRequest
@@ -229,8 +359,19 @@ func (db *Database) ResizeCluster(rcr ResizeClusterRequest) error {
 	// generate empty context
 	ctx := context.TODO()
 
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if err := rcr.validate(); err != nil {
+			return errors.New("Unable to resize cluster " + rcr.Id + ". " + err.Error())
+		}
+		db.dryRun.record("Databases.Resize", rcr.Id, resize)
+		return nil
+	}
+
 	// send resize request
-	_, err := db.client.Resize(ctx, rcr.Id, resize)
+	ctx, op := db.observer.begin(ctx, "Databases.Resize", slog.String("cluster_id", rcr.Id), slog.String("size", resize.SizeSlug), logRequest(resize))
+	resp, err := db.client.Resize(ctx, rcr.Id, resize)
+	op.end(resp, err)
 	if err != nil {
 		return errors.New("Unable to resize cluster " + rcr.Id + ". Godo error: " + err.Error())
 	}
@@ -248,8 +389,19 @@ func (db *Database) MigrateToNewRegion(mrr MigrateRegionRequest) error {
 	// generate new client and create empty context
 	ctx := context.TODO()
 
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if err := mrr.validate(); err != nil {
+			return errors.New("Unable to migrate to new region. " + err.Error())
+		}
+		db.dryRun.record("Databases.Migrate", mrr.Id, migrate)
+		return nil
+	}
+
 	// send migrate request
-	_, err := db.client.Migrate(ctx, mrr.Id, migrate)
+	ctx, op := db.observer.begin(ctx, "Databases.Migrate", slog.String("cluster_id", mrr.Id), slog.String("region", migrate.Region), logRequest(migrate))
+	resp, err := db.client.Migrate(ctx, mrr.Id, migrate)
+	op.end(resp, err)
 	if err != nil {
 		return errors.New("Unable to migrate to new region. Godo error: " + err.Error())
 	}
@@ -268,8 +420,19 @@ func (db *Database) ConfigureMaintenanceWindow(umw UpdateMaintenanceWindowReques
 	// create empty context
 	ctx := context.TODO()
 
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if err := umw.validate(); err != nil {
+			return errors.New("Unable to configure maintenance window for database cluster " + umw.Id + ". " + err.Error())
+		}
+		db.dryRun.record("Databases.UpdateMaintenance", umw.Id, configure)
+		return nil
+	}
+
 	// send update maintanence window request
-	_, err := db.client.UpdateMaintenance(ctx, umw.Id, configure)
+	ctx, op := db.observer.begin(ctx, "Databases.UpdateMaintenance", slog.String("cluster_id", umw.Id), logRequest(configure))
+	resp, err := db.client.UpdateMaintenance(ctx, umw.Id, configure)
+	op.end(resp, err)
 	if err != nil {
 		return errors.New("Unable to configure maintenance window for database cluster." + umw.Id + " Godo error: " + err.Error())
 	}
@@ -287,8 +450,19 @@ func (db *Database) AddDatabaseToCluster(cdb CreateDatabaseRequest) (*godo.Datab
 	// create empty context
 	ctx := context.TODO()
 
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if err := cdb.validate(); err != nil {
+			return nil, errors.New("Unable to add database to cluster. " + err.Error())
+		}
+		db.dryRun.record("Databases.CreateDB", cdb.ClusterID, create)
+		return &godo.DatabaseDB{Name: cdb.Name}, nil
+	}
+
 	// add database to cluster
-	database, _, err := db.client.CreateDB(ctx, cdb.ClusterID, create)
+	ctx, op := db.observer.begin(ctx, "Databases.CreateDB", slog.String("cluster_id", cdb.ClusterID), slog.String("database", cdb.Name))
+	database, resp, err := db.client.CreateDB(ctx, cdb.ClusterID, create)
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to add database to cluster. Godo error: " + err.Error())
 	}
@@ -302,7 +476,9 @@ func (db *Database) FindAllDatabasesInCluster(clusterID string) ([]godo.Database
 	ctx := context.TODO()
 
 	// find all databases by cluser id
-	dbs, _, err := db.client.ListDBs(ctx, clusterID, nil)
+	ctx, op := db.observer.begin(ctx, "Databases.ListDBs", slog.String("cluster_id", clusterID))
+	dbs, resp, err := db.client.ListDBs(ctx, clusterID, nil)
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to find all databases in cluster: " + clusterID + " . Godo error:  " + err.Error())
 	}
@@ -315,8 +491,19 @@ func (db *Database) DeleteDatabaseInCluster(dr DeleteDatabaseRequest) error {
 	// create empty context
 	ctx := context.TODO()
 
+	// log the request instead of sending it
+	if db.dryRun != nil {
+		if err := dr.validate(); err != nil {
+			return errors.New("Unable to delete database: " + dr.Name + " . " + err.Error())
+		}
+		db.dryRun.record("Databases.DeleteDB", dr.ClusterID, dr.Name)
+		return nil
+	}
+
 	// send delete database request
-	_, err := db.client.DeleteDB(ctx, dr.ClusterID, dr.Name)
+	ctx, op := db.observer.begin(ctx, "Databases.DeleteDB", slog.String("cluster_id", dr.ClusterID), slog.String("database", dr.Name))
+	resp, err := db.client.DeleteDB(ctx, dr.ClusterID, dr.Name)
+	op.end(resp, err)
 	if err != nil {
 		return errors.New("Unable to delete database: " + dr.Name + " . Godo error: " + err.Error())
 	}
@@ -146,6 +146,42 @@ func TestCreateDatabaseCluster(t *testing.T) {
 
 }
 
+func TestCreateDatabaseClusterInVPC(t *testing.T) {
+
+	t.Run("Error is thrown when VPC is in another region", func(t *testing.T) {
+		dbClient := NewDBC(TestPAT)
+		dbClient.client = &MockGodoDatabaseSvc{}
+		dbClient.vpcs = &MockGodoVPCSvc{}
+
+		request := TestCreateDatabaseClusterRequest
+		request.VPC = TestVPC.ID
+		expectedError := "Unable to create database cluster. VPC: " + TestVPC.ID + " is in nyc2, not fra1"
+		_, returnedError := dbClient.Create(request)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected: %s returned: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestCreateDatabaseClusterInProject(t *testing.T) {
+
+	t.Run("Error is thrown when project is missing", func(t *testing.T) {
+		dbClient := NewDBC(TestPAT)
+		dbClient.client = &MockGodoDatabaseSvc{}
+		dbClient.projects = &MockGodoProjectSvc{}
+
+		request := TestCreateDatabaseClusterRequest
+		request.Project = "missing"
+		expectedError := "Unable to create database cluster. Project: missing, was not found"
+		_, returnedError := dbClient.Create(request)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected: %s returned: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
 func TestResizeCluster(t *testing.T) {
 
 	t.Run("Error is thrown", func(t *testing.T) {
@@ -232,7 +268,51 @@ func TestDeleteDatabaseInCluster(t *testing.T) {
 
 }
 
-type MockGodoDatabaseSvc struct{}
+func TestDeleteCluster(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		dbClient := NewDBC(TestPAT)
+		dbClient.client = &MockGodoDatabaseSvc{}
+
+		expectedError := "Unable to delete database cluster 1. Godo error: " + TestError
+		returnedError := dbClient.DeleteCluster("1").Error()
+		if expectedError != returnedError {
+			t.Errorf("expected: %s\n returned: %s\n", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestParseSlugs(t *testing.T) {
+
+	region, _ := ParseRegion("ams3")
+	if region != AMS3 {
+		t.Errorf("expected %+v\n , returned, %+v\n ", AMS3, region)
+	}
+
+	size, _ := ParseDatabaseSize("db-s-2vcpu-4gb")
+	if size != DbS2Cpu4GbRAM38GbStorage {
+		t.Errorf("expected %+v\n , returned, %+v\n ", DbS2Cpu4GbRAM38GbStorage, size)
+	}
+
+	engine, _ := ParseDatabaseType("mysql")
+	if engine != MySQL {
+		t.Errorf("expected %+v\n , returned, %+v\n ", MySQL, engine)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "pg15 is not a database engine. Use one of pg, redis, mysql"
+		_, returnedError := ParseDatabaseType("pg15")
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoDatabaseSvc struct {
+	listed []godo.Database
+}
 
 func (m *MockGodoDatabaseSvc) Get(context.Context, string) (*godo.Database, *godo.Response, error) {
 	return &ExpectedDB, nil, nil
@@ -255,6 +335,9 @@ func (m *MockGodoDatabaseSvc) GetDB(context.Context, string, string) (*godo.Data
 }
 
 func (m *MockGodoDatabaseSvc) List(context.Context, *godo.ListOptions) ([]godo.Database, *godo.Response, error) {
+	if m.listed != nil {
+		return m.listed, nil, nil
+	}
 	return ExpectedDBs, nil, nil
 }
 
@@ -263,6 +346,10 @@ func (m *MockGodoDatabaseSvc) ListDBs(context.Context, string, *godo.ListOptions
 
 }
 
+func (m *MockGodoDatabaseSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
 func (m *MockGodoDatabaseSvc) Migrate(context.Context, string, *godo.DatabaseMigrateRequest) (*godo.Response, error) {
 	return nil, errors.New(TestError)
 
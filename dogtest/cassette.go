@@ -0,0 +1,293 @@
+package dogtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/evancaplan/dog"
+)
+
+const redacted = "REDACTED"
+
+// Interaction is one request and the response the API gave to it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper that either records the interactions sent
+// through it to a file or replays the interactions recorded in one. Bearer
+// tokens and database passwords are scrubbed before anything is recorded.
+//
+//	cassette := dogtest.NewRecorder("testdata/droplets.json", nil)
+//	defer cassette.Save()
+//	droplets := dog.NewDC(token, cassette.Option())
+type Cassette struct {
+	path      string
+	recording bool
+	base      http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	played       []bool
+}
+
+// NewRecorder records the interactions sent to base, or to the network when
+// base is nil, until Save writes them to path.
+func NewRecorder(path string, base http.RoundTripper) *Cassette {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Cassette{path: path, recording: true, base: base}
+}
+
+// LoadCassette replays the interactions recorded to path. Each request is
+// answered by the first unplayed interaction with the same method, path,
+// query and body, so repeated requests replay in the order recorded.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("Unable to read cassette " + path + ". " + err.Error())
+	}
+
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, errors.New("Unable to read cassette " + path + ". " + err.Error())
+	}
+
+	return &Cassette{path: path, interactions: interactions, played: make([]bool, len(interactions))}, nil
+}
+
+// Option sends a dog client's requests through the cassette.
+func (c *Cassette) Option() dog.Option {
+	return dog.WithTransport(c)
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette path, readable only
+// by the owner.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.recording {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return errors.New("Unable to save cassette " + c.path + ". " + err.Error())
+	}
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return errors.New("Unable to save cassette " + c.path + ". " + err.Error())
+	}
+	return nil
+}
+
+func (c *Cassette) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+	request := RecordedRequest{
+		Method:  r.Method,
+		URL:     r.URL.String(),
+		Headers: scrubHeaders(r.Header),
+		Body:    scrubBody(body),
+	}
+
+	if c.recording {
+		return c.record(r, request)
+	}
+	return c.replay(r, request)
+}
+
+func (c *Cassette) record(r *http.Request, request RecordedRequest) (*http.Response, error) {
+	resp, err := c.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{
+		Request: request,
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+			Body:    scrubBody(body),
+		},
+	})
+	return resp, nil
+}
+
+func (c *Cassette) replay(r *http.Request, request RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.played[i] || !matches(interaction.Request, request) {
+			continue
+		}
+		c.played[i] = true
+
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        strconv.Itoa(recorded.Status) + " " + http.StatusText(recorded.Status),
+			StatusCode:    recorded.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       r,
+		}, nil
+	}
+
+	return nil, errors.New("No interaction in cassette " + c.path + " matches " + request.Method + " " + request.URL)
+}
+
+// matches compares requests by method, path, query and body, ignoring the
+// host so a cassette recorded against the API replays against any base URL.
+func matches(recorded RecordedRequest, request RecordedRequest) bool {
+	if recorded.Method != request.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	requestURL, err := url.Parse(request.URL)
+	if err != nil {
+		return false
+	}
+	if recordedURL.Path != requestURL.Path || recordedURL.Query().Encode() != requestURL.Query().Encode() {
+		return false
+	}
+
+	return normalizeJSON(recorded.Body) == normalizeJSON(request.Body)
+}
+
+// readBody reads body and replaces it so it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func scrubHeaders(headers http.Header) http.Header {
+	scrubbed := headers.Clone()
+	if scrubbed.Get("Authorization") != "" {
+		scrubbed.Set("Authorization", "Bearer "+redacted)
+	}
+	return scrubbed
+}
+
+// scrubBody redacts every password in a JSON body, including the ones in
+// connection URIs.
+func scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(scrubbed)
+}
+
+func scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch {
+			case strings.EqualFold(key, "password"):
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = redacted
+				}
+			case strings.EqualFold(key, "uri"):
+				if s, ok := field.(string); ok {
+					v[key] = scrubURI(s)
+				}
+			default:
+				v[key] = scrubValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func scrubURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+	if _, ok := u.User.Password(); !ok {
+		return uri
+	}
+	u.User = url.UserPassword(u.User.Username(), redacted)
+	return u.String()
+}
+
+func normalizeJSON(body string) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	normalized, _ := json.Marshal(value)
+	return string(normalized)
+}
@@ -0,0 +1,95 @@
+package dogtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+const TestPassword = "s3cr3t-password"
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := NewServer()
+	server.Databases.AddCluster(godo.Database{
+		ID:         "orders",
+		Name:       "orders",
+		EngineSlug: "pg",
+		Connection: &godo.DatabaseConnection{
+			URI:      "postgresql://doadmin:" + TestPassword + "@orders.db.ondigitalocean.com:25060/defaultdb?sslmode=require",
+			User:     "doadmin",
+			Password: TestPassword,
+		},
+	})
+
+	recorder := NewRecorder(path, nil)
+	dClient := dog.NewDC(TestToken, server.Option(), recorder.Option())
+	dbClient := dog.NewDBC(TestToken, server.Option(), recorder.Option())
+
+	created, err := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "web", Region: dog.NYC3, DropletSize: dog.S1Cpu1GbRAM, Image: "ubuntu-22-04-x64"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	recorded, err := dbClient.GetById("orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if recorded.Connection.Password != TestPassword {
+		t.Errorf("expected the recorder to pass the password through, returned %+v", recorded.Connection)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	server.Close()
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), TestToken) || strings.Contains(string(data), TestPassword) {
+		t.Errorf("expected the token and password to be scrubbed, returned %s", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, returned %v", info.Mode().Perm())
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dClient = dog.NewDC(TestToken, cassette.Option())
+	dbClient = dog.NewDBC(TestToken, cassette.Option())
+
+	replayed, err := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "web", Region: dog.NYC3, DropletSize: dog.S1Cpu1GbRAM, Image: "ubuntu-22-04-x64"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if replayed.ID != created.ID || replayed.Name != "web" {
+		t.Errorf("expected %+v\n , returned, %+v\n ", created, replayed)
+	}
+
+	cluster, err := dbClient.GetById("orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cluster.Connection.Password != redacted || !strings.Contains(cluster.Connection.URI, "doadmin:"+redacted+"@") {
+		t.Errorf("expected a scrubbed connection, returned %+v", cluster.Connection)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		_, returnedError := dbClient.GetById("orders")
+		if returnedError == nil || !strings.Contains(returnedError.Error(), "No interaction in cassette") {
+			t.Errorf("expected the played interaction not to replay twice, returned %v", returnedError)
+		}
+
+		_, returnedError = dClient.CreateDroplet(dog.CreateDropletRequest{Name: "db", Region: dog.NYC3, DropletSize: dog.S1Cpu1GbRAM, Image: "ubuntu-22-04-x64"})
+		if returnedError == nil {
+			t.Errorf("expected a request with a different body not to match")
+		}
+	})
+
+}
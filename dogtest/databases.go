@@ -0,0 +1,278 @@
+package dogtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+var _ dog.DatabaseClient = (*DatabaseFake)(nil)
+
+// DatabaseFake implements dog.DatabaseClient over clusters held in memory.
+// Created clusters are online straight away with a "defaultdb" database.
+type DatabaseFake struct {
+	faults
+
+	mu       sync.Mutex
+	clusters []godo.Database
+	dbs      map[string][]godo.DatabaseDB
+	lastID   int
+}
+
+func NewDatabaseFake() *DatabaseFake {
+	return &DatabaseFake{dbs: map[string][]godo.DatabaseDB{}}
+}
+
+// AddCluster seeds the fake with cluster, assigning an ID when it has none.
+func (f *DatabaseFake) AddCluster(cluster godo.Database) godo.Database {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if cluster.ID == "" {
+		cluster.ID = f.nextID()
+	}
+	f.clusters = append(f.clusters, cluster)
+	for _, name := range cluster.DBNames {
+		f.dbs[cluster.ID] = append(f.dbs[cluster.ID], godo.DatabaseDB{Name: name})
+	}
+	return cluster
+}
+
+// Clusters returns a copy of every cluster held by the fake.
+func (f *DatabaseFake) Clusters() []godo.Database {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]godo.Database(nil), f.clusters...)
+}
+
+func (f *DatabaseFake) List(ctx context.Context, opt *godo.ListOptions) ([]godo.Database, *godo.Response, error) {
+	if err := f.before(ctx, "List"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	start, end, resp := page("/v2/databases", opt, len(f.clusters))
+	return append([]godo.Database(nil), f.clusters[start:end]...), resp, nil
+}
+
+func (f *DatabaseFake) Get(ctx context.Context, id string) (*godo.Database, *godo.Response, error) {
+	if err := f.before(ctx, "Get"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/databases/"+id)
+		return nil, resp, err
+	}
+	cluster := f.clusters[i]
+	return &cluster, ok(), nil
+}
+
+func (f *DatabaseFake) Create(ctx context.Context, request *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error) {
+	if err := f.before(ctx, "Create"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.nextID()
+	host := request.Name + "-do-user-0.db.ondigitalocean.com"
+	cluster := godo.Database{
+		ID:          id,
+		Name:        request.Name,
+		EngineSlug:  request.EngineSlug,
+		VersionSlug: request.Version,
+		Connection: &godo.DatabaseConnection{
+			Protocol: request.EngineSlug,
+			Host:     host,
+			Port:     25060,
+			User:     "doadmin",
+			Database: "defaultdb",
+			SSL:      true,
+		},
+		NumNodes:           request.NumNodes,
+		SizeSlug:           request.SizeSlug,
+		DBNames:            []string{"defaultdb"},
+		RegionSlug:         request.Region,
+		Status:             "online",
+		CreatedAt:          time.Now().UTC(),
+		PrivateNetworkUUID: request.PrivateNetworkUUID,
+		Tags:               append([]string(nil), request.Tags...),
+	}
+
+	f.clusters = append(f.clusters, cluster)
+	f.dbs[id] = []godo.DatabaseDB{{Name: "defaultdb"}}
+	return &cluster, ok(), nil
+}
+
+func (f *DatabaseFake) Delete(ctx context.Context, id string) (*godo.Response, error) {
+	if err := f.before(ctx, "Delete"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		return notFound(http.MethodDelete, "/v2/databases/"+id)
+	}
+	f.clusters = append(f.clusters[:i], f.clusters[i+1:]...)
+	delete(f.dbs, id)
+	return &godo.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+}
+
+func (f *DatabaseFake) Resize(ctx context.Context, id string, request *godo.DatabaseResizeRequest) (*godo.Response, error) {
+	if err := f.before(ctx, "Resize"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		return notFound(http.MethodPut, "/v2/databases/"+id+"/resize")
+	}
+	f.clusters[i].SizeSlug = request.SizeSlug
+	f.clusters[i].NumNodes = request.NumNodes
+	return accepted(), nil
+}
+
+func (f *DatabaseFake) Migrate(ctx context.Context, id string, request *godo.DatabaseMigrateRequest) (*godo.Response, error) {
+	if err := f.before(ctx, "Migrate"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		return notFound(http.MethodPut, "/v2/databases/"+id+"/migrate")
+	}
+	f.clusters[i].RegionSlug = request.Region
+	if request.PrivateNetworkUUID != "" {
+		f.clusters[i].PrivateNetworkUUID = request.PrivateNetworkUUID
+	}
+	return accepted(), nil
+}
+
+func (f *DatabaseFake) UpdateMaintenance(ctx context.Context, id string, request *godo.DatabaseUpdateMaintenanceRequest) (*godo.Response, error) {
+	if err := f.before(ctx, "UpdateMaintenance"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		return notFound(http.MethodPut, "/v2/databases/"+id+"/maintenance")
+	}
+	f.clusters[i].MaintenanceWindow = &godo.DatabaseMaintenanceWindow{Day: request.Day, Hour: request.Hour}
+	return &godo.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+}
+
+func (f *DatabaseFake) ListDBs(ctx context.Context, id string, opt *godo.ListOptions) ([]godo.DatabaseDB, *godo.Response, error) {
+	if err := f.before(ctx, "ListDBs"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.index(id) < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/databases/"+id+"/dbs")
+		return nil, resp, err
+	}
+	dbs := f.dbs[id]
+	start, end, resp := page("/v2/databases/"+id+"/dbs", opt, len(dbs))
+	return append([]godo.DatabaseDB(nil), dbs[start:end]...), resp, nil
+}
+
+func (f *DatabaseFake) CreateDB(ctx context.Context, id string, request *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error) {
+	if err := f.before(ctx, "CreateDB"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		resp, err := notFound(http.MethodPost, "/v2/databases/"+id+"/dbs")
+		return nil, resp, err
+	}
+	db := godo.DatabaseDB{Name: request.Name}
+	f.dbs[id] = append(f.dbs[id], db)
+	f.clusters[i].DBNames = append(f.clusters[i].DBNames, request.Name)
+	return &db, &godo.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
+func (f *DatabaseFake) GetDB(ctx context.Context, id string, name string) (*godo.DatabaseDB, *godo.Response, error) {
+	if err := f.before(ctx, "GetDB"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, db := range f.dbs[id] {
+		if db.Name == name {
+			return &db, ok(), nil
+		}
+	}
+	resp, err := notFound(http.MethodGet, "/v2/databases/"+id+"/dbs/"+name)
+	return nil, resp, err
+}
+
+func (f *DatabaseFake) DeleteDB(ctx context.Context, id string, name string) (*godo.Response, error) {
+	if err := f.before(ctx, "DeleteDB"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	for j, db := range f.dbs[id] {
+		if db.Name == name {
+			f.dbs[id] = append(f.dbs[id][:j], f.dbs[id][j+1:]...)
+			f.clusters[i].DBNames = removeName(f.clusters[i].DBNames, name)
+			return &godo.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+		}
+	}
+	return notFound(http.MethodDelete, "/v2/databases/"+id+"/dbs/"+name)
+}
+
+func (f *DatabaseFake) index(id string) int {
+	for i, cluster := range f.clusters {
+		if cluster.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextID returns a UUID shaped ID that is unique within the fake.
+func (f *DatabaseFake) nextID() string {
+	f.lastID++
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", f.lastID, f.lastID)
+}
+
+func accepted() *godo.Response {
+	return &godo.Response{Response: &http.Response{StatusCode: http.StatusAccepted}}
+}
+
+func removeName(names []string, name string) []string {
+	var kept []string
+	for _, n := range names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,77 @@
+package dogtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+func TestDatabaseFakeState(t *testing.T) {
+
+	fake := NewDatabaseFake()
+	dbClient := dog.NewDBCWithClient(fake)
+
+	cluster, err := dbClient.Create(dog.CreateDatabaseClusterRequest{Name: "orders", DatabaseType: dog.PostGres, DatabaseSize: dog.DbS1Cpu1GbRAM10GbStorage, Region: dog.NYC3, NumNodes: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cluster.Status != "online" || cluster.RegionSlug != "nyc3" {
+		t.Errorf("unexpected cluster: %+v", cluster)
+	}
+
+	err = dbClient.ResizeCluster(dog.ResizeClusterRequest{Id: cluster.ID, DatabaseSize: dog.DbS2Cpu4GbRAM38GbStorage, NumNodes: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resized, _ := dbClient.GetById(cluster.ID)
+	if resized.SizeSlug != "db-s-2vcpu-4gb" || resized.NumNodes != 2 {
+		t.Errorf("expected the cluster to be resized, returned %+v", resized)
+	}
+
+	_, err = dbClient.AddDatabaseToCluster(dog.CreateDatabaseRequest{Name: "reports", ClusterID: cluster.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []godo.DatabaseDB{{Name: "defaultdb"}, {Name: "reports"}}
+	returned, _ := dbClient.FindAllDatabasesInCluster(cluster.ID)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	err = dbClient.DeleteDatabaseInCluster(dog.DeleteDatabaseRequest{Name: "reports", ClusterID: cluster.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	remaining, _, _ := fake.GetDB(context.Background(), cluster.ID, "reports")
+	if remaining != nil {
+		t.Errorf("expected reports to be deleted, returned %+v", remaining)
+	}
+
+	err = dbClient.DeleteCluster(cluster.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if clusters := fake.Clusters(); len(clusters) != 0 {
+		t.Errorf("expected the cluster to be deleted, returned %+v", clusters)
+	}
+
+}
+
+func TestDatabaseFakeFaults(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		fake := NewDatabaseFake()
+		fake.InjectError("List", errors.New("internal server error"))
+		dbClient := dog.NewDBCWithClient(fake)
+
+		_, returnedError := dbClient.GetAll(1, 20)
+		if returnedError == nil {
+			t.Errorf("expected the injected error to be returned")
+		}
+	})
+
+}
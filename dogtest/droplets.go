@@ -0,0 +1,256 @@
+package dogtest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+var _ dog.DropletClient = (*DropletFake)(nil)
+
+// DropletFake implements dog.DropletClient over droplets held in memory.
+// Created droplets get increasing IDs and are active straight away.
+type DropletFake struct {
+	faults
+
+	mu       sync.Mutex
+	droplets []godo.Droplet
+	backups  map[int][]godo.Image
+	lastID   int
+}
+
+func NewDropletFake() *DropletFake {
+	return &DropletFake{backups: map[int][]godo.Image{}}
+}
+
+// AddDroplet seeds the fake with droplet, assigning an ID when it has none.
+func (f *DropletFake) AddDroplet(droplet godo.Droplet) godo.Droplet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if droplet.ID == 0 {
+		f.lastID++
+		droplet.ID = f.lastID
+	} else if droplet.ID > f.lastID {
+		f.lastID = droplet.ID
+	}
+	f.droplets = append(f.droplets, droplet)
+	return droplet
+}
+
+// AddBackup seeds a backup image of the droplet with id.
+func (f *DropletFake) AddBackup(id int, backup godo.Image) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.backups[id] = append(f.backups[id], backup)
+	for i := range f.droplets {
+		if f.droplets[i].ID == id {
+			f.droplets[i].BackupIDs = append(f.droplets[i].BackupIDs, backup.ID)
+		}
+	}
+}
+
+// Droplets returns a copy of every droplet held by the fake.
+func (f *DropletFake) Droplets() []godo.Droplet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]godo.Droplet(nil), f.droplets...)
+}
+
+func (f *DropletFake) List(ctx context.Context, opt *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	if err := f.before(ctx, "List"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	start, end, resp := page("/v2/droplets", opt, len(f.droplets))
+	return append([]godo.Droplet(nil), f.droplets[start:end]...), resp, nil
+}
+
+func (f *DropletFake) ListByTag(ctx context.Context, tag string, opt *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	if err := f.before(ctx, "ListByTag"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var tagged []godo.Droplet
+	for _, droplet := range f.droplets {
+		if hasTag(droplet.Tags, tag) {
+			tagged = append(tagged, droplet)
+		}
+	}
+
+	start, end, resp := page("/v2/droplets?tag_name="+url.QueryEscape(tag), opt, len(tagged))
+	return tagged[start:end], resp, nil
+}
+
+func (f *DropletFake) Get(ctx context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+	if err := f.before(ctx, "Get"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/droplets/"+strconv.Itoa(id))
+		return nil, resp, err
+	}
+	droplet := f.droplets[i]
+	return &droplet, ok(), nil
+}
+
+func (f *DropletFake) Create(ctx context.Context, request *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error) {
+	if err := f.before(ctx, "Create"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastID++
+	droplet := godo.Droplet{
+		ID:       f.lastID,
+		Name:     request.Name,
+		Status:   "active",
+		Region:   &godo.Region{Slug: request.Region},
+		SizeSlug: request.Size,
+		Size:     &godo.Size{Slug: request.Size},
+		Image:    &godo.Image{Slug: request.Image.Slug},
+		Tags:     append([]string(nil), request.Tags...),
+		VPCUUID:  request.VPCUUID,
+		Created:  time.Now().UTC().Format(time.RFC3339),
+		Networks: &godo.Networks{
+			V4: []godo.NetworkV4{
+				{IPAddress: "203.0.113." + strconv.Itoa(f.lastID%254+1), Type: "public"},
+				{IPAddress: "10.10.0." + strconv.Itoa(f.lastID%254+1), Type: "private"},
+			},
+		},
+	}
+	for _, volume := range request.Volumes {
+		droplet.VolumeIDs = append(droplet.VolumeIDs, volume.ID)
+	}
+	if request.Backups {
+		droplet.Features = append(droplet.Features, "backups")
+	}
+	if request.IPv6 {
+		droplet.Features = append(droplet.Features, "ipv6")
+	}
+	if request.Monitoring {
+		droplet.Features = append(droplet.Features, "monitoring")
+	}
+
+	f.droplets = append(f.droplets, droplet)
+	return &droplet, ok(), nil
+}
+
+func (f *DropletFake) Delete(ctx context.Context, id int) (*godo.Response, error) {
+	if err := f.before(ctx, "Delete"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		return notFound(http.MethodDelete, "/v2/droplets/"+strconv.Itoa(id))
+	}
+	f.droplets = append(f.droplets[:i], f.droplets[i+1:]...)
+	delete(f.backups, id)
+	return &godo.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+}
+
+func (f *DropletFake) Backups(ctx context.Context, id int, opt *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	if err := f.before(ctx, "Backups"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.index(id) < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/droplets/"+strconv.Itoa(id)+"/backups")
+		return nil, resp, err
+	}
+	backups := f.backups[id]
+	start, end, resp := page("/v2/droplets/"+strconv.Itoa(id)+"/backups", opt, len(backups))
+	return append([]godo.Image(nil), backups[start:end]...), resp, nil
+}
+
+// Snapshots returns no snapshots; the fake does not take them.
+func (f *DropletFake) Snapshots(ctx context.Context, id int, opt *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	if err := f.before(ctx, "Snapshots"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.index(id) < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/droplets/"+strconv.Itoa(id)+"/snapshots")
+		return nil, resp, err
+	}
+	_, _, resp := page("/v2/droplets/"+strconv.Itoa(id)+"/snapshots", opt, 0)
+	return []godo.Image{}, resp, nil
+}
+
+// Kernels returns the droplet's kernel, if it has one.
+func (f *DropletFake) Kernels(ctx context.Context, id int, opt *godo.ListOptions) ([]godo.Kernel, *godo.Response, error) {
+	if err := f.before(ctx, "Kernels"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.index(id)
+	if i < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/droplets/"+strconv.Itoa(id)+"/kernels")
+		return nil, resp, err
+	}
+	kernels := []godo.Kernel{}
+	if f.droplets[i].Kernel != nil {
+		kernels = append(kernels, *f.droplets[i].Kernel)
+	}
+	start, end, resp := page("/v2/droplets/"+strconv.Itoa(id)+"/kernels", opt, len(kernels))
+	return kernels[start:end], resp, nil
+}
+
+// Neighbors returns no droplets; every fake droplet has a host to itself.
+func (f *DropletFake) Neighbors(ctx context.Context, id int) ([]godo.Droplet, *godo.Response, error) {
+	if err := f.before(ctx, "Neighbors"); err != nil {
+		return nil, nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.index(id) < 0 {
+		resp, err := notFound(http.MethodGet, "/v2/droplets/"+strconv.Itoa(id)+"/neighbors")
+		return nil, resp, err
+	}
+	return []godo.Droplet{}, ok(), nil
+}
+
+func (f *DropletFake) index(id int) int {
+	for i, droplet := range f.droplets {
+		if droplet.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package dogtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+func TestDropletFakeState(t *testing.T) {
+
+	fake := NewDropletFake()
+	dClient := dog.NewDCWithClient(fake)
+
+	web, err := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "web", Region: dog.NYC3, DropletSize: dog.S1Cpu1GbRAM, Image: "ubuntu-22-04-x64", Tags: []string{"web"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	worker, _ := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "worker", Region: dog.NYC3, DropletSize: dog.S2Cpu4GbRAM, Image: "ubuntu-22-04-x64"})
+	if web.ID != 1 || worker.ID != 2 {
+		t.Errorf("expected ids 1 and 2, returned %d and %d", web.ID, worker.ID)
+	}
+
+	returned, _ := dClient.GetDropletById(dog.FindDropletByIDRequest{ID: web.ID})
+	if !reflect.DeepEqual(web, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", web, returned)
+	}
+
+	tagged, _ := dClient.GetDropletsByTag(dog.FindDropletsByTagRequest{Tag: "web"})
+	if len(*tagged) != 1 || (*tagged)[0].ID != web.ID {
+		t.Errorf("expected only web to be tagged, returned %+v", *tagged)
+	}
+
+	err = dClient.DeleteDroplet(dog.DeleteDropletRequest{ID: web.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	all, _ := dClient.GetAllDroplets(dog.FindAllDropletsRequest{})
+	if len(all) != 1 || all[0].ID != worker.ID {
+		t.Errorf("expected only worker to remain, returned %+v", all)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Droplet with id: 1, was not found. Godo error: GET https://api.digitalocean.com/v2/droplets/1: 404 The resource you were accessing could not be found."
+		_, returnedError := dClient.GetDropletById(dog.FindDropletByIDRequest{ID: web.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestDropletFakePagination(t *testing.T) {
+
+	fake := NewDropletFake()
+	for i := 0; i < 5; i++ {
+		fake.AddDroplet(godo.Droplet{Name: "droplet"})
+	}
+
+	droplets, resp, _ := fake.List(context.Background(), &godo.ListOptions{Page: 2, PerPage: 2})
+	if len(droplets) != 2 || droplets[0].ID != 3 {
+		t.Errorf("expected droplets 3 and 4, returned %+v", droplets)
+	}
+	if resp.Links.IsLastPage() || resp.Meta.Total != 5 {
+		t.Errorf("expected more pages of 5 droplets, returned %+v %+v", resp.Links.Pages, resp.Meta)
+	}
+
+	droplets, resp, _ = fake.List(context.Background(), &godo.ListOptions{Page: 3, PerPage: 2})
+	if len(droplets) != 1 || !resp.Links.IsLastPage() {
+		t.Errorf("expected the last page to hold droplet 5, returned %+v", droplets)
+	}
+
+}
+
+func TestDropletFakeFaults(t *testing.T) {
+
+	fake := NewDropletFake()
+	dClient := dog.NewDCWithClient(fake)
+
+	fake.InjectError("Create", errors.New("rate limited"))
+	expectedError := "Unable to create droplet. Godo error: rate limited"
+	_, returnedError := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "web"})
+	if returnedError == nil || expectedError != returnedError.Error() {
+		t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+	}
+
+	fake.ClearErrors()
+	fake.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, _, err := fake.List(ctx, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to pass before the latency, returned %v", err)
+	}
+
+}
+
+func TestDropletFakeRestore(t *testing.T) {
+
+	fake := NewDropletFake()
+	droplet := fake.AddDroplet(godo.Droplet{Name: "web"})
+	fake.AddBackup(droplet.ID, godo.Image{ID: 7, Type: "backup"})
+
+	dClient := dog.NewDCWithClient(fake)
+	backups, _ := dClient.ListBackups(dog.FindDropletBackupsRequest{ID: droplet.ID})
+	if len(backups) != 1 || backups[0].ID != 7 {
+		t.Errorf("expected backup 7, returned %+v", backups)
+	}
+
+	expectedError := "Unable to restore droplet with id: 1. Droplet actions are unavailable on this client"
+	_, returnedError := dClient.RestoreFromBackup(dog.RestoreDropletRequest{ID: droplet.ID, BackupID: 7})
+	if returnedError == nil || expectedError != returnedError.Error() {
+		t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+	}
+
+}
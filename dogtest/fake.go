@@ -0,0 +1,138 @@
+// Package dogtest provides stateful in-memory fakes of the DigitalOcean API,
+// and a local server serving them over HTTP, for testing code built on dog
+// without a network.
+//
+//	fake := dogtest.NewDropletFake()
+//	droplets := dog.NewDCWithClient(fake)
+package dogtest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 200
+	apiURL         = "https://api.digitalocean.com"
+)
+
+// faults holds the errors and latency injected into a fake. Errors are keyed
+// by method name, e.g. "Create", and are returned until cleared.
+type faults struct {
+	mu      sync.Mutex
+	errs    map[string]error
+	latency time.Duration
+}
+
+// InjectError makes every call to method return err.
+func (f *faults) InjectError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.errs == nil {
+		f.errs = map[string]error{}
+	}
+	f.errs[method] = err
+}
+
+// ClearErrors removes every injected error.
+func (f *faults) ClearErrors() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errs = nil
+}
+
+// SetLatency delays every call by latency, or until its context is done.
+func (f *faults) SetLatency(latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = latency
+}
+
+func (f *faults) before(ctx context.Context, method string) error {
+	f.mu.Lock()
+	err := f.errs[method]
+	latency := f.latency
+	f.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(latency):
+		}
+	}
+	return err
+}
+
+// page returns the bounds of the requested page of total items and the
+// response describing it, with links the way the API sets them.
+func page(path string, opt *godo.ListOptions, total int) (int, int, *godo.Response) {
+	number, perPage := 1, defaultPerPage
+	if opt != nil {
+		if opt.Page > 0 {
+			number = opt.Page
+		}
+		if opt.PerPage > 0 {
+			perPage = opt.PerPage
+		}
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	start := (number - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	last := (total + perPage - 1) / perPage
+	pages := &godo.Pages{}
+	if number > 1 {
+		pages.First = pageURL(path, 1, perPage)
+		pages.Prev = pageURL(path, number-1, perPage)
+	}
+	if number < last {
+		pages.Next = pageURL(path, number+1, perPage)
+		pages.Last = pageURL(path, last, perPage)
+	}
+
+	resp := &godo.Response{
+		Response: &http.Response{StatusCode: http.StatusOK},
+		Links:    &godo.Links{Pages: pages},
+		Meta:     &godo.Meta{Total: total},
+	}
+	return start, end, resp
+}
+
+func pageURL(path string, number int, perPage int) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return apiURL + path + separator + "page=" + strconv.Itoa(number) + "&per_page=" + strconv.Itoa(perPage)
+}
+
+func ok() *godo.Response {
+	return &godo.Response{Response: &http.Response{StatusCode: http.StatusOK}}
+}
+
+// notFound is the error the API returns for a missing resource.
+func notFound(method string, path string) (*godo.Response, error) {
+	request, _ := http.NewRequest(method, apiURL+path, nil)
+	response := &http.Response{StatusCode: http.StatusNotFound, Request: request}
+	return &godo.Response{Response: response}, &godo.ErrorResponse{
+		Response: response,
+		Message:  "The resource you were accessing could not be found.",
+	}
+}
@@ -0,0 +1,453 @@
+package dogtest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+const defaultRateLimit = 5000
+
+// Server emulates the /v2/droplets and /v2/databases endpoints of the
+// DigitalOcean API over HTTP, keeping its state in Droplets and Databases.
+// Point a client at it with Option:
+//
+//	server := dogtest.NewServer()
+//	defer server.Close()
+//	droplets := dog.NewDC("token", server.Option())
+type Server struct {
+	*httptest.Server
+	Droplets  *DropletFake
+	Databases *DatabaseFake
+
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	requests  int
+}
+
+func NewServer() *Server {
+	s := &Server{
+		Droplets:  NewDropletFake(),
+		Databases: NewDatabaseFake(),
+		limit:     defaultRateLimit,
+		remaining: defaultRateLimit,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/droplets", s.listDroplets)
+	mux.HandleFunc("POST /v2/droplets", s.createDroplet)
+	mux.HandleFunc("GET /v2/droplets/{id}", s.getDroplet)
+	mux.HandleFunc("DELETE /v2/droplets/{id}", s.deleteDroplet)
+	mux.HandleFunc("GET /v2/droplets/{id}/backups", s.listBackups)
+	mux.HandleFunc("GET /v2/droplets/{id}/snapshots", s.listSnapshots)
+	mux.HandleFunc("GET /v2/droplets/{id}/kernels", s.listKernels)
+	mux.HandleFunc("GET /v2/droplets/{id}/neighbors", s.listNeighbors)
+	mux.HandleFunc("GET /v2/databases", s.listDatabases)
+	mux.HandleFunc("POST /v2/databases", s.createDatabase)
+	mux.HandleFunc("GET /v2/databases/{id}", s.getDatabase)
+	mux.HandleFunc("DELETE /v2/databases/{id}", s.deleteDatabase)
+	mux.HandleFunc("PUT /v2/databases/{id}/resize", s.resizeDatabase)
+	mux.HandleFunc("PUT /v2/databases/{id}/migrate", s.migrateDatabase)
+	mux.HandleFunc("PUT /v2/databases/{id}/maintenance", s.updateMaintenance)
+	mux.HandleFunc("GET /v2/databases/{id}/dbs", s.listDBs)
+	mux.HandleFunc("POST /v2/databases/{id}/dbs", s.createDB)
+	mux.HandleFunc("GET /v2/databases/{id}/dbs/{name}", s.getDB)
+	mux.HandleFunc("DELETE /v2/databases/{id}/dbs/{name}", s.deleteDB)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "The resource you were accessing could not be found.")
+	})
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Option points a dog client at the server.
+func (s *Server) Option() dog.Option {
+	u, _ := url.Parse(s.URL)
+	return dog.WithBaseURL(u)
+}
+
+// SetRateLimit allows limit requests before the server answers 429.
+func (s *Server) SetRateLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+	s.remaining = limit
+}
+
+// middleware authenticates requests, counts them against the rate limit and
+// sets the headers every API response carries.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		requestID := "dogtest-" + strconv.Itoa(s.requests)
+		limited := s.remaining <= 0
+		if !limited {
+			s.remaining--
+		}
+		limit, remaining := s.limit, s.remaining
+		s.mu.Unlock()
+
+		w.Header().Set("x-request-id", requestID)
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") == "" {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unable to authenticate you.")
+			return
+		}
+		if limited {
+			writeError(w, http.StatusTooManyRequests, "too_many_requests", "API Rate limit exceeded.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+type dropletCreateBody struct {
+	Name              string            `json:"name"`
+	Region            string            `json:"region"`
+	Size              string            `json:"size"`
+	Image             json.RawMessage   `json:"image"`
+	Backups           bool              `json:"backups"`
+	IPv6              bool              `json:"ipv6"`
+	PrivateNetworking bool              `json:"private_networking"`
+	Monitoring        bool              `json:"monitoring"`
+	UserData          string            `json:"user_data"`
+	Volumes           []json.RawMessage `json:"volumes"`
+	Tags              []string          `json:"tags"`
+	VPCUUID           string            `json:"vpc_uuid"`
+}
+
+func (s *Server) listDroplets(w http.ResponseWriter, r *http.Request) {
+	opt := listOptions(r)
+
+	var droplets []godo.Droplet
+	var resp *godo.Response
+	var err error
+	if tag := r.URL.Query().Get("tag_name"); tag != "" {
+		droplets, resp, err = s.Droplets.ListByTag(r.Context(), tag, opt)
+	} else {
+		droplets, resp, err = s.Droplets.List(r.Context(), opt)
+	}
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"droplets": nonNil(droplets),
+		"links":    s.links(resp),
+		"meta":     resp.Meta,
+	})
+}
+
+func (s *Server) createDroplet(w http.ResponseWriter, r *http.Request) {
+	var body dropletCreateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+
+	request := &godo.DropletCreateRequest{
+		Name:              body.Name,
+		Region:            body.Region,
+		Size:              body.Size,
+		Backups:           body.Backups,
+		IPv6:              body.IPv6,
+		PrivateNetworking: body.PrivateNetworking,
+		Monitoring:        body.Monitoring,
+		UserData:          body.UserData,
+		Tags:              body.Tags,
+		VPCUUID:           body.VPCUUID,
+	}
+	if err := json.Unmarshal(body.Image, &request.Image.Slug); err != nil {
+		json.Unmarshal(body.Image, &request.Image.ID)
+	}
+	for _, raw := range body.Volumes {
+		var volume struct {
+			ID string `json:"id"`
+		}
+		json.Unmarshal(raw, &volume)
+		request.Volumes = append(request.Volumes, godo.DropletCreateVolume{ID: volume.ID})
+	}
+
+	droplet, _, err := s.Droplets.Create(r.Context(), request)
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"droplet": droplet})
+}
+
+func (s *Server) getDroplet(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	droplet, _, err := s.Droplets.Get(r.Context(), id)
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"droplet": droplet})
+}
+
+func (s *Server) deleteDroplet(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := s.Droplets.Delete(r.Context(), id); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listBackups(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	backups, resp, err := s.Droplets.Backups(r.Context(), id, listOptions(r))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"backups": nonNil(backups), "links": s.links(resp), "meta": resp.Meta})
+}
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	snapshots, resp, err := s.Droplets.Snapshots(r.Context(), id, listOptions(r))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"snapshots": nonNil(snapshots), "links": s.links(resp), "meta": resp.Meta})
+}
+
+func (s *Server) listKernels(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	kernels, resp, err := s.Droplets.Kernels(r.Context(), id, listOptions(r))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"kernels": nonNil(kernels), "links": s.links(resp), "meta": resp.Meta})
+}
+
+func (s *Server) listNeighbors(w http.ResponseWriter, r *http.Request) {
+	id, ok := dropletID(w, r)
+	if !ok {
+		return
+	}
+	neighbors, _, err := s.Droplets.Neighbors(r.Context(), id)
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"droplets": nonNil(neighbors)})
+}
+
+func (s *Server) listDatabases(w http.ResponseWriter, r *http.Request) {
+	databases, resp, err := s.Databases.List(r.Context(), listOptions(r))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"databases": nonNil(databases), "links": s.links(resp), "meta": resp.Meta})
+}
+
+func (s *Server) createDatabase(w http.ResponseWriter, r *http.Request) {
+	var request godo.DatabaseCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+	database, _, err := s.Databases.Create(r.Context(), &request)
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"database": database})
+}
+
+func (s *Server) getDatabase(w http.ResponseWriter, r *http.Request) {
+	database, _, err := s.Databases.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"database": database})
+}
+
+func (s *Server) deleteDatabase(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.Databases.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resizeDatabase(w http.ResponseWriter, r *http.Request) {
+	var request godo.DatabaseResizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+	if _, err := s.Databases.Resize(r.Context(), r.PathValue("id"), &request); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) migrateDatabase(w http.ResponseWriter, r *http.Request) {
+	var request godo.DatabaseMigrateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+	if _, err := s.Databases.Migrate(r.Context(), r.PathValue("id"), &request); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) updateMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request godo.DatabaseUpdateMaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+	if _, err := s.Databases.UpdateMaintenance(r.Context(), r.PathValue("id"), &request); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDBs(w http.ResponseWriter, r *http.Request) {
+	dbs, _, err := s.Databases.ListDBs(r.Context(), r.PathValue("id"), listOptions(r))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"dbs": nonNil(dbs)})
+}
+
+func (s *Server) createDB(w http.ResponseWriter, r *http.Request) {
+	var request godo.DatabaseCreateDBRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Unable to parse request body.")
+		return
+	}
+	db, _, err := s.Databases.CreateDB(r.Context(), r.PathValue("id"), &request)
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"db": db})
+}
+
+func (s *Server) getDB(w http.ResponseWriter, r *http.Request) {
+	db, _, err := s.Databases.GetDB(r.Context(), r.PathValue("id"), r.PathValue("name"))
+	if err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"db": db})
+}
+
+func (s *Server) deleteDB(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.Databases.DeleteDB(r.Context(), r.PathValue("id"), r.PathValue("name")); err != nil {
+		writeFakeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// links rewrites the page links of resp to point at the server.
+func (s *Server) links(resp *godo.Response) *godo.Links {
+	if resp == nil || resp.Links == nil || resp.Links.Pages == nil {
+		return &godo.Links{}
+	}
+	pages := *resp.Links.Pages
+	for _, link := range []*string{&pages.First, &pages.Prev, &pages.Next, &pages.Last} {
+		*link = strings.Replace(*link, apiURL, s.URL, 1)
+	}
+	return &godo.Links{Pages: &pages}
+}
+
+func listOptions(r *http.Request) *godo.ListOptions {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	return &godo.ListOptions{Page: page, PerPage: perPage}
+}
+
+func dropletID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "The resource you were accessing could not be found.")
+		return 0, false
+	}
+	return id, true
+}
+
+// nonNil keeps empty lists encoding as [] the way the API sends them.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, id string, message string) {
+	writeJSON(w, status, map[string]string{
+		"id":         id,
+		"message":    message,
+		"request_id": w.Header().Get("x-request-id"),
+	})
+}
+
+// writeFakeError answers with the status of a fake's API error, or 500 for
+// errors injected into it.
+func writeFakeError(w http.ResponseWriter, err error) {
+	var apiErr *godo.ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.Response != nil {
+		id := "not_found"
+		if apiErr.Response.StatusCode != http.StatusNotFound {
+			id = "unprocessable_entity"
+		}
+		writeError(w, apiErr.Response.StatusCode, id, apiErr.Message)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+}
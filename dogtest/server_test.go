@@ -0,0 +1,161 @@
+package dogtest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/evancaplan/dog"
+)
+
+const TestToken = "dogtest-token"
+
+func TestServerDroplets(t *testing.T) {
+
+	server := NewServer()
+	defer server.Close()
+
+	dClient := dog.NewDC(TestToken, server.Option())
+
+	created, err := dClient.CreateDroplet(dog.CreateDropletRequest{Name: "web", Region: dog.NYC3, DropletSize: dog.S1Cpu1GbRAM, Image: "ubuntu-22-04-x64", Volumes: []string{"vol-1"}, Tags: []string{"web"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created.Image.Slug != "ubuntu-22-04-x64" || len(created.VolumeIDs) != 1 || created.Region.Slug != "nyc3" {
+		t.Errorf("unexpected droplet: %+v", created)
+	}
+
+	returned, err := dClient.GetDropletById(dog.FindDropletByIDRequest{ID: created.ID})
+	if err != nil || returned.Name != "web" {
+		t.Errorf("expected droplet web, returned %+v %v", returned, err)
+	}
+
+	tagged, _ := dClient.GetDropletsByTag(dog.FindDropletsByTagRequest{Tag: "web", Page: 1, PerPage: 10})
+	if len(*tagged) != 1 {
+		t.Errorf("expected one tagged droplet, returned %+v", *tagged)
+	}
+
+	err = dClient.DeleteDroplet(dog.DeleteDropletRequest{ID: created.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		_, returnedError := dClient.GetDropletById(dog.FindDropletByIDRequest{ID: created.ID})
+		if returnedError == nil || !strings.HasSuffix(returnedError.Error(), "/v2/droplets/1: 404 (request \"dogtest-5\") The resource you were accessing could not be found.") {
+			t.Errorf("unexpected error: %s", returnedError)
+		}
+	})
+
+}
+
+func TestServerPagination(t *testing.T) {
+
+	server := NewServer()
+	defer server.Close()
+	for i := 0; i < 3; i++ {
+		server.Droplets.AddDroplet(godo.Droplet{Name: "droplet"})
+	}
+
+	client := dog.Authenticate(TestToken, server.Option())
+	droplets, resp, err := client.Droplets.List(t.Context(), &godo.ListOptions{Page: 1, PerPage: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(droplets) != 2 || resp.Meta.Total != 3 || resp.Links.IsLastPage() {
+		t.Errorf("expected the first of two pages, returned %+v %+v", droplets, resp.Links.Pages)
+	}
+	if !strings.HasPrefix(resp.Links.Pages.Next, server.URL+"/v2/droplets?page=2") {
+		t.Errorf("expected the next page on the server, returned %s", resp.Links.Pages.Next)
+	}
+	if resp.Rate.Limit != defaultRateLimit || resp.Rate.Remaining != defaultRateLimit-1 {
+		t.Errorf("unexpected rate: %+v", resp.Rate)
+	}
+
+	// following the links of a tagged listing stays on the tagged droplets
+	for i := 0; i < 3; i++ {
+		server.Droplets.AddDroplet(godo.Droplet{Name: "web", Tags: []string{"web tier"}})
+	}
+	tagged, resp, err := client.Droplets.ListByTag(t.Context(), "web tier", &godo.ListOptions{Page: 1, PerPage: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(resp.Links.Pages.Next, server.URL+"/v2/droplets?tag_name=web+tier&page=2") {
+		t.Errorf("expected the next page of tagged droplets, returned %s", resp.Links.Pages.Next)
+	}
+	request, _ := client.NewRequest(t.Context(), http.MethodGet, resp.Links.Pages.Next, nil)
+	var next struct {
+		Droplets []godo.Droplet `json:"droplets"`
+	}
+	if _, err := client.Do(t.Context(), request, &next); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tagged = append(tagged, next.Droplets...)
+	if len(tagged) != 3 || tagged[2].Name != "web" {
+		t.Errorf("expected the three tagged droplets, returned %+v", tagged)
+	}
+
+}
+
+func TestServerDatabases(t *testing.T) {
+
+	server := NewServer()
+	defer server.Close()
+
+	dbClient := dog.NewDBC(TestToken, server.Option())
+
+	cluster, err := dbClient.Create(dog.CreateDatabaseClusterRequest{Name: "orders", DatabaseType: dog.PostGres, Version: "16", DatabaseSize: dog.DbS1Cpu1GbRAM10GbStorage, Region: dog.NYC3, NumNodes: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = dbClient.ResizeCluster(dog.ResizeClusterRequest{Id: cluster.ID, DatabaseSize: dog.DbS2Cpu4GbRAM38GbStorage, NumNodes: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = dbClient.AddDatabaseToCluster(dog.CreateDatabaseRequest{Name: "reports", ClusterID: cluster.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dbs, _ := dbClient.FindAllDatabasesInCluster(cluster.ID)
+	if len(dbs) != 2 {
+		t.Errorf("expected defaultdb and reports, returned %+v", dbs)
+	}
+
+	returned, _ := dbClient.GetById(cluster.ID)
+	if returned.SizeSlug != "db-s-2vcpu-4gb" || returned.NumNodes != 2 {
+		t.Errorf("expected the cluster to be resized, returned %+v", returned)
+	}
+
+}
+
+func TestServerErrors(t *testing.T) {
+
+	server := NewServer()
+	defer server.Close()
+
+	client := dog.Authenticate(TestToken, server.Option())
+
+	server.Droplets.InjectError("List", errors.New("database is on fire"))
+	_, resp, err := client.Droplets.List(t.Context(), nil)
+	var apiErr *godo.ErrorResponse
+	if !errors.As(err, &apiErr) || resp.StatusCode != http.StatusInternalServerError || apiErr.Message != "database is on fire" {
+		t.Errorf("expected a server error, returned %v", err)
+	}
+
+	server.SetRateLimit(0)
+	_, resp, err = client.Droplets.List(t.Context(), nil)
+	if err == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected to be rate limited, returned %v", err)
+	}
+
+	server.SetRateLimit(10)
+	_, resp, err = dog.Authenticate("", server.Option()).Droplets.List(t.Context(), nil)
+	if err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected to be unauthorized, returned %v", err)
+	}
+
+}
@@ -0,0 +1,461 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateDomainRequest struct {
+	Name      string
+	IPAddress string
+}
+
+type FindAllDomainsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type DeleteDomainRequest struct {
+	Name string
+}
+
+type FindRecordsRequest struct {
+	Domain string
+	RecordType
+	Name    string
+	Page    int
+	PerPage int
+}
+
+type CreateRecordRequest struct {
+	Domain string
+	RecordType
+	Name     string
+	Data     string
+	Priority int
+	Port     int
+	TTL      int
+	Weight   int
+	Flags    int
+	Tag      string
+}
+
+type UpdateRecordRequest struct {
+	Domain string
+	ID     int
+	RecordType
+	Name     string
+	Data     string
+	Priority int
+	Port     int
+	TTL      int
+	Weight   int
+	Flags    int
+	Tag      string
+}
+
+type DeleteRecordRequest struct {
+	Domain string
+	ID     int
+}
+
+type RegisterDropletRequest struct {
+	Domain  string
+	Name    string
+	TTL     int
+	Droplet godo.Droplet
+}
+
+type SyncRecordsRequest struct {
+	Domain  string
+	Records []godo.DomainRecord
+	// Prune deletes every record in the domain that is not in Records.
+	// Without it only records sharing a type and name with Records are removed.
+	Prune  bool
+	DryRun bool
+}
+
+type SyncRecordsResult struct {
+	Created []godo.DomainRecord
+	Updated []godo.DomainRecord
+	Deleted []godo.DomainRecord
+}
+
+// Record types
+type RecordType int
+
+const (
+	AnyRecord RecordType = iota
+	ARecord
+	AAAARecord
+	CNAMERecord
+	TXTRecord
+	MXRecord
+	SRVRecord
+	CAARecord
+)
+
+func (rt RecordType) String() string {
+	names := [...]string{
+		"",
+		"A",
+		"AAAA",
+		"CNAME",
+		"TXT",
+		"MX",
+		"SRV",
+		"CAA",
+	}
+	if rt < AnyRecord || rt > CAARecord {
+		return "That is not a record type"
+	}
+	return names[rt]
+}
+
+type DomainClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.Domain, *godo.Response, error)
+	Get(context.Context, string) (*godo.Domain, *godo.Response, error)
+	Create(context.Context, *godo.DomainCreateRequest) (*godo.Domain, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	Records(context.Context, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error)
+	RecordsByType(context.Context, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error)
+	RecordsByName(context.Context, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error)
+	RecordsByTypeAndName(context.Context, string, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error)
+	CreateRecord(context.Context, string, *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error)
+	EditRecord(context.Context, string, int, *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error)
+	DeleteRecord(context.Context, string, int) (*godo.Response, error)
+}
+
+type Domain struct {
+	client DomainClient
+}
+
+func NewDNC(pat string) Domain {
+	client := Authenticate(pat)
+	return Domain{client: client.Domains}
+}
+
+func (d *Domain) GetAllDomains(fadr FindAllDomainsRequest) ([]godo.Domain, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fadr.Page,
+		PerPage: fadr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	domains, _, err := d.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all domains. Godo error: " + err.Error())
+	}
+
+	return domains, nil
+}
+
+func (d *Domain) GetDomain(name string) (*godo.Domain, error) {
+
+	ctx := context.TODO()
+
+	domain, _, err := d.client.Get(ctx, name)
+	if err != nil {
+		return nil, errors.New("Domain: " + name + ", was not found. Godo error: " + err.Error())
+	}
+
+	return domain, nil
+}
+
+func (d *Domain) CreateDomain(cdr CreateDomainRequest) (*godo.Domain, error) {
+
+	create := &godo.DomainCreateRequest{
+		Name:      cdr.Name,
+		IPAddress: cdr.IPAddress,
+	}
+
+	ctx := context.TODO()
+
+	domain, _, err := d.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create domain. Godo error: " + err.Error())
+	}
+
+	return domain, nil
+}
+
+func (d *Domain) DeleteDomain(ddr DeleteDomainRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := d.client.Delete(ctx, ddr.Name)
+	if err != nil {
+		return errors.New("Unable to delete domain: " + ddr.Name + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (d *Domain) GetRecords(frr FindRecordsRequest) ([]godo.DomainRecord, error) {
+
+	opt := &godo.ListOptions{
+		Page:    frr.Page,
+		PerPage: frr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	var records []godo.DomainRecord
+	var err error
+	switch {
+	case frr.RecordType != AnyRecord && frr.Name != "":
+		records, _, err = d.client.RecordsByTypeAndName(ctx, frr.Domain, frr.RecordType.String(), frr.Name, opt)
+	case frr.RecordType != AnyRecord:
+		records, _, err = d.client.RecordsByType(ctx, frr.Domain, frr.RecordType.String(), opt)
+	case frr.Name != "":
+		records, _, err = d.client.RecordsByName(ctx, frr.Domain, frr.Name, opt)
+	default:
+		records, _, err = d.client.Records(ctx, frr.Domain, opt)
+	}
+	if err != nil {
+		return nil, errors.New("Unable to get records for domain: " + frr.Domain + ". Godo error: " + err.Error())
+	}
+
+	return records, nil
+}
+
+func (d *Domain) CreateRecord(crr CreateRecordRequest) (*godo.DomainRecord, error) {
+
+	create := &godo.DomainRecordEditRequest{
+		Type:     crr.RecordType.String(),
+		Name:     crr.Name,
+		Data:     crr.Data,
+		Priority: crr.Priority,
+		Port:     crr.Port,
+		TTL:      crr.TTL,
+		Weight:   crr.Weight,
+		Flags:    crr.Flags,
+		Tag:      crr.Tag,
+	}
+
+	ctx := context.TODO()
+
+	record, _, err := d.client.CreateRecord(ctx, crr.Domain, create)
+	if err != nil {
+		return nil, errors.New("Unable to create record for domain: " + crr.Domain + ". Godo error: " + err.Error())
+	}
+
+	return record, nil
+}
+
+func (d *Domain) UpdateRecord(urr UpdateRecordRequest) (*godo.DomainRecord, error) {
+
+	edit := &godo.DomainRecordEditRequest{
+		Type:     urr.RecordType.String(),
+		Name:     urr.Name,
+		Data:     urr.Data,
+		Priority: urr.Priority,
+		Port:     urr.Port,
+		TTL:      urr.TTL,
+		Weight:   urr.Weight,
+		Flags:    urr.Flags,
+		Tag:      urr.Tag,
+	}
+
+	ctx := context.TODO()
+
+	record, _, err := d.client.EditRecord(ctx, urr.Domain, urr.ID, edit)
+	if err != nil {
+		return nil, errors.New("Unable to update record with ID: " + strconv.Itoa(urr.ID) + ". Godo error: " + err.Error())
+	}
+
+	return record, nil
+}
+
+func (d *Domain) DeleteRecord(drr DeleteRecordRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := d.client.DeleteRecord(ctx, drr.Domain, drr.ID)
+	if err != nil {
+		return errors.New("Unable to delete record with ID: " + strconv.Itoa(drr.ID) + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+// RegisterDroplet points A and AAAA records for Name at the droplet's public
+// addresses, replacing stale records of the same type held by that name.
+func (d *Domain) RegisterDroplet(rdr RegisterDropletRequest) (*SyncRecordsResult, error) {
+
+	if rdr.Droplet.Networks == nil {
+		return nil, errors.New("Droplet with id: " + strconv.Itoa(rdr.Droplet.ID) + " has no networks to register")
+	}
+
+	var records []godo.DomainRecord
+	for _, v4 := range rdr.Droplet.Networks.V4 {
+		if v4.Type == "public" {
+			records = append(records, godo.DomainRecord{Type: ARecord.String(), Name: rdr.Name, Data: v4.IPAddress, TTL: rdr.TTL})
+		}
+	}
+	for _, v6 := range rdr.Droplet.Networks.V6 {
+		if v6.Type == "public" {
+			records = append(records, godo.DomainRecord{Type: AAAARecord.String(), Name: rdr.Name, Data: v6.IPAddress, TTL: rdr.TTL})
+		}
+	}
+	if len(records) == 0 {
+		return nil, errors.New("Droplet with id: " + strconv.Itoa(rdr.Droplet.ID) + " has no public addresses to register")
+	}
+
+	return d.SyncRecords(SyncRecordsRequest{
+		Domain:  rdr.Domain,
+		Records: records,
+	})
+}
+
+// SyncRecords makes the records of a domain match the desired set, reporting
+// what was created, updated and deleted. With DryRun nothing is changed.
+func (d *Domain) SyncRecords(srr SyncRecordsRequest) (*SyncRecordsResult, error) {
+
+	ctx := context.TODO()
+
+	existing, err := d.allRecords(ctx, srr.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	managed := map[string]bool{}
+	for _, r := range srr.Records {
+		managed[recordKey(r)] = true
+	}
+
+	leftover := map[int]godo.DomainRecord{}
+	for _, r := range existing {
+		leftover[r.ID] = r
+	}
+
+	// exact matches on type, name and data are kept or updated in place
+	result := &SyncRecordsResult{}
+	var unmatched []godo.DomainRecord
+	for _, want := range srr.Records {
+		found := false
+		for id, have := range leftover {
+			if recordKey(have) == recordKey(want) && have.Data == want.Data {
+				delete(leftover, id)
+				found = true
+				if !recordEqual(have, want) {
+					want.ID = id
+					result.Updated = append(result.Updated, want)
+				}
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, want)
+		}
+	}
+
+	// remaining desired records reuse an existing record of the same type and name
+	for _, want := range unmatched {
+		reused := false
+		for _, have := range existing {
+			if _, ok := leftover[have.ID]; ok && recordKey(have) == recordKey(want) {
+				delete(leftover, have.ID)
+				want.ID = have.ID
+				result.Updated = append(result.Updated, want)
+				reused = true
+				break
+			}
+		}
+		if !reused {
+			result.Created = append(result.Created, want)
+		}
+	}
+
+	for _, have := range existing {
+		if _, ok := leftover[have.ID]; !ok {
+			continue
+		}
+		if have.Type == "SOA" || have.Type == "NS" {
+			continue
+		}
+		if srr.Prune || managed[recordKey(have)] {
+			result.Deleted = append(result.Deleted, have)
+		}
+	}
+
+	if srr.DryRun {
+		return result, nil
+	}
+
+	for i, r := range result.Created {
+		created, _, err := d.client.CreateRecord(ctx, srr.Domain, recordEditRequest(r))
+		if err != nil {
+			return result, errors.New("Unable to create record for domain: " + srr.Domain + ". Godo error: " + err.Error())
+		}
+		result.Created[i] = *created
+	}
+	for i, r := range result.Updated {
+		updated, _, err := d.client.EditRecord(ctx, srr.Domain, r.ID, recordEditRequest(r))
+		if err != nil {
+			return result, errors.New("Unable to update record with ID: " + strconv.Itoa(r.ID) + ". Godo error: " + err.Error())
+		}
+		result.Updated[i] = *updated
+	}
+	for _, r := range result.Deleted {
+		_, err := d.client.DeleteRecord(ctx, srr.Domain, r.ID)
+		if err != nil {
+			return result, errors.New("Unable to delete record with ID: " + strconv.Itoa(r.ID) + ". Godo error: " + err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+func (d *Domain) allRecords(ctx context.Context, domain string) ([]godo.DomainRecord, error) {
+	var records []godo.DomainRecord
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := d.client.Records(ctx, domain, opt)
+		if err != nil {
+			return nil, errors.New("Unable to get records for domain: " + domain + ". Godo error: " + err.Error())
+		}
+		records = append(records, page...)
+		if isLastPage(resp) {
+			return records, nil
+		}
+		opt.Page++
+	}
+}
+
+func isLastPage(resp *godo.Response) bool {
+	return resp == nil || resp.Links == nil || resp.Links.IsLastPage()
+}
+
+func recordKey(r godo.DomainRecord) string {
+	name := r.Name
+	if name == "" {
+		name = "@"
+	}
+	return r.Type + " " + name
+}
+
+func recordEqual(a, b godo.DomainRecord) bool {
+	if b.TTL != 0 && a.TTL != b.TTL {
+		return false
+	}
+	return a.Priority == b.Priority && a.Port == b.Port && a.Weight == b.Weight && a.Flags == b.Flags && a.Tag == b.Tag
+}
+
+func recordEditRequest(r godo.DomainRecord) *godo.DomainRecordEditRequest {
+	return &godo.DomainRecordEditRequest{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     r.Data,
+		Priority: r.Priority,
+		Port:     r.Port,
+		TTL:      r.TTL,
+		Weight:   r.Weight,
+		Flags:    r.Flags,
+		Tag:      r.Tag,
+	}
+}
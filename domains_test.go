@@ -0,0 +1,231 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestDomain = godo.Domain{
+	Name: "example.com",
+	TTL:  1800,
+}
+
+var TestDomains = []godo.Domain{TestDomain}
+
+var TestDomainRecord = godo.DomainRecord{
+	ID:   1,
+	Type: "A",
+	Name: "www",
+	Data: "10.0.0.1",
+	TTL:  1800,
+}
+
+var TestDomainRecords = []godo.DomainRecord{TestDomainRecord}
+
+var TestCreateDomainRequest = CreateDomainRequest{
+	Name:      "example.com",
+	IPAddress: "10.0.0.1",
+}
+
+var TestFindRecordsRequest = FindRecordsRequest{
+	Domain:     "example.com",
+	RecordType: ARecord,
+	Page:       1,
+	PerPage:    5,
+}
+
+var TestCreateRecordRequest = CreateRecordRequest{
+	Domain:     "example.com",
+	RecordType: ARecord,
+	Name:       "www",
+	Data:       "10.0.0.1",
+	TTL:        1800,
+}
+
+var TestDeleteRecordRequest = DeleteRecordRequest{
+	Domain: "example.com",
+	ID:     1,
+}
+
+var TestNetworkedDroplet = godo.Droplet{
+	ID: 1,
+	Networks: &godo.Networks{
+		V4: []godo.NetworkV4{
+			{IPAddress: "10.0.0.2", Type: "public"},
+			{IPAddress: "192.168.0.2", Type: "private"},
+		},
+		V6: []godo.NetworkV6{
+			{IPAddress: "2604:a880::1", Type: "public"},
+		},
+	},
+}
+
+func TestGetAllDomains(t *testing.T) {
+
+	dnClient := NewDNC(TestPAT)
+	dnClient.client = &MockGodoDomainSvc{}
+
+	expected := TestDomains
+	returned, _ := dnClient.GetAllDomains(FindAllDomainsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateDomain(t *testing.T) {
+
+	dnClient := NewDNC(TestPAT)
+	dnClient.client = &MockGodoDomainSvc{}
+
+	expected := &TestDomain
+	returned, _ := dnClient.CreateDomain(TestCreateDomainRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetRecords(t *testing.T) {
+
+	dnClient := NewDNC(TestPAT)
+	dnClient.client = &MockGodoDomainSvc{}
+
+	expected := TestDomainRecords
+	returned, _ := dnClient.GetRecords(TestFindRecordsRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateRecord(t *testing.T) {
+
+	dnClient := NewDNC(TestPAT)
+	dnClient.client = &MockGodoDomainSvc{}
+
+	expected := &TestDomainRecord
+	returned, _ := dnClient.CreateRecord(TestCreateRecordRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteRecord(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		dnClient := NewDNC(TestPAT)
+		dnClient.client = &MockGodoDomainSvc{}
+
+		expectedError := "Unable to delete record with ID: " + strconv.Itoa(TestDeleteRecordRequest.ID) + ". Godo error: " + TestError
+		returnedError := dnClient.DeleteRecord(TestDeleteRecordRequest)
+		if expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestSyncRecords(t *testing.T) {
+
+	t.Run("Records are created, updated and deleted", func(t *testing.T) {
+		dnClient := NewDNC(TestPAT)
+		dnClient.client = &MockGodoDomainSvc{}
+
+		desired := []godo.DomainRecord{
+			{Type: "A", Name: "www", Data: "10.0.0.9", TTL: 1800},
+			{Type: "TXT", Name: "@", Data: "v=spf1 -all", TTL: 1800},
+		}
+		result, err := dnClient.SyncRecords(SyncRecordsRequest{Domain: "example.com", Records: desired, Prune: true, DryRun: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := &SyncRecordsResult{
+			Created: []godo.DomainRecord{desired[1]},
+			Updated: []godo.DomainRecord{{ID: 1, Type: "A", Name: "www", Data: "10.0.0.9", TTL: 1800}},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, result)
+		}
+	})
+
+	t.Run("Matching records are left alone", func(t *testing.T) {
+		dnClient := NewDNC(TestPAT)
+		dnClient.client = &MockGodoDomainSvc{}
+
+		result, _ := dnClient.SyncRecords(SyncRecordsRequest{Domain: "example.com", Records: TestDomainRecords, DryRun: true})
+		if len(result.Created)+len(result.Updated)+len(result.Deleted) != 0 {
+			t.Errorf("expected no changes, returned %+v\n", result)
+		}
+	})
+
+}
+
+func TestRegisterDroplet(t *testing.T) {
+
+	dnClient := NewDNC(TestPAT)
+	dnClient.client = &MockGodoDomainSvc{}
+
+	expected := &SyncRecordsResult{
+		Created: []godo.DomainRecord{TestDomainRecord},
+		Updated: []godo.DomainRecord{TestDomainRecord},
+	}
+	returned, _ := dnClient.RegisterDroplet(RegisterDropletRequest{Domain: "example.com", Name: "www", Droplet: TestNetworkedDroplet})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+type MockGodoDomainSvc struct{}
+
+func (m *MockGodoDomainSvc) List(context.Context, *godo.ListOptions) ([]godo.Domain, *godo.Response, error) {
+	return TestDomains, nil, nil
+}
+
+func (m *MockGodoDomainSvc) Get(context.Context, string) (*godo.Domain, *godo.Response, error) {
+	return &TestDomain, nil, nil
+}
+
+func (m *MockGodoDomainSvc) Create(context.Context, *godo.DomainCreateRequest) (*godo.Domain, *godo.Response, error) {
+	return &TestDomain, nil, nil
+}
+
+func (m *MockGodoDomainSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoDomainSvc) Records(context.Context, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+	return TestDomainRecords, nil, nil
+}
+
+func (m *MockGodoDomainSvc) RecordsByType(context.Context, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+	return TestDomainRecords, nil, nil
+}
+
+func (m *MockGodoDomainSvc) RecordsByName(context.Context, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+	return TestDomainRecords, nil, nil
+}
+
+func (m *MockGodoDomainSvc) RecordsByTypeAndName(context.Context, string, string, string, *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+	return TestDomainRecords, nil, nil
+}
+
+func (m *MockGodoDomainSvc) CreateRecord(context.Context, string, *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error) {
+	return &TestDomainRecord, nil, nil
+}
+
+func (m *MockGodoDomainSvc) EditRecord(context.Context, string, int, *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error) {
+	return &TestDomainRecord, nil, nil
+}
+
+func (m *MockGodoDomainSvc) DeleteRecord(context.Context, string, int) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
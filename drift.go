@@ -0,0 +1,202 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// Drift is one way a live resource differs from its spec. Resources in the
+// spec that do not exist drift in their presence, with Have "missing".
+type Drift struct {
+	Resource string
+	Name     string
+	ID       string `json:",omitempty"`
+	Field    string
+	Want     string
+	Have     string
+}
+
+// DriftReport is the drift of the resources in a spec. Resources counts the
+// resources checked and Drifted those with at least one Drift.
+type DriftReport struct {
+	Resources int
+	Drifted   int
+	Drifts    []Drift
+}
+
+const missing = "missing"
+
+// HasDrift reports whether any resource differs from the spec.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+// Summary is a single line describing the drift, e.g. for a CI log.
+func (r *DriftReport) Summary() string {
+	if !r.HasDrift() {
+		return "No drift in " + strconv.Itoa(r.Resources) + " resources."
+	}
+	return "Drift: " + strconv.Itoa(len(r.Drifts)) + " differences in " + strconv.Itoa(r.Drifted) + " of " + strconv.Itoa(r.Resources) + " resources."
+}
+
+// String prints a line per difference followed by the summary.
+func (r *DriftReport) String() string {
+	var b strings.Builder
+	for _, d := range r.Drifts {
+		name := d.Resource + " " + d.Name
+		if d.ID != "" {
+			name += " (" + d.ID + ")"
+		}
+		if d.Have == missing {
+			b.WriteString("- " + name + ": missing\n")
+			continue
+		}
+		b.WriteString("~ " + name + ": " + d.Field + " is " + d.Have + ", want " + d.Want + "\n")
+	}
+	if r.HasDrift() {
+		b.WriteString("\n")
+	}
+	b.WriteString(r.Summary() + "\n")
+	return b.String()
+}
+
+// DetectDrift compares the droplets and database clusters in spec with the
+// live ones of the same name without changing anything. Live resources that
+// are not in the spec are not reported.
+func (i *Infrastructure) DetectDrift(spec Spec) (*DriftReport, error) {
+
+	ctx := context.TODO()
+
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+
+	droplets, err := i.allDroplets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := i.allClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{Resources: len(spec.Droplets) + len(spec.Databases)}
+	add := func(drifts []Drift) {
+		if len(drifts) > 0 {
+			report.Drifted++
+			report.Drifts = append(report.Drifts, drifts...)
+		}
+	}
+
+	for _, want := range spec.Droplets {
+		found := false
+		var drifts []Drift
+		for _, have := range droplets {
+			if have.Name == want.Name {
+				found = true
+				drifts = append(drifts, dropletDrift(want, have)...)
+			}
+		}
+		if !found {
+			drifts = append(drifts, Drift{Resource: dropletResource, Name: want.Name, Field: "presence", Want: "present", Have: missing})
+		}
+		add(drifts)
+	}
+
+	for _, want := range spec.Databases {
+		found := false
+		var drifts []Drift
+		for _, have := range clusters {
+			if have.Name != want.Name {
+				continue
+			}
+			found = true
+			clusterDrifts, err := i.clusterDrift(ctx, want, have)
+			if err != nil {
+				return nil, err
+			}
+			drifts = append(drifts, clusterDrifts...)
+		}
+		if !found {
+			drifts = append(drifts, Drift{Resource: databaseResource, Name: want.Name, Field: "presence", Want: "present", Have: missing})
+		}
+		add(drifts)
+	}
+
+	return report, nil
+}
+
+func dropletDrift(want CreateDropletRequest, have godo.Droplet) []Drift {
+
+	region := "none"
+	if have.Region != nil && have.Region.Slug != "" {
+		region = have.Region.Slug
+	}
+
+	return differences(dropletResource, want.Name, strconv.Itoa(have.ID), [][3]string{
+		{"size", want.DropletSize.String(), have.SizeSlug},
+		{"region", want.Region.String(), region},
+		{"tags", setString(want.Tags), setString(have.Tags)},
+	})
+}
+
+func (i *Infrastructure) clusterDrift(ctx context.Context, want DatabaseClusterSpec, have godo.Database) ([]Drift, error) {
+
+	fields := [][3]string{
+		{"size", want.DatabaseSize.String(), have.SizeSlug},
+		{"region", want.Region.String(), have.RegionSlug},
+		{"nodes", strconv.Itoa(want.NumNodes), strconv.Itoa(have.NumNodes)},
+		{"tags", setString(want.Tags), setString(have.Tags)},
+	}
+
+	if want.MaintenanceWindow != nil && !sameWindow(have.MaintenanceWindow, want.MaintenanceWindow) {
+		window := "none"
+		if have.MaintenanceWindow != nil {
+			window = have.MaintenanceWindow.Day + " " + have.MaintenanceWindow.Hour
+		}
+		fields = append(fields, [3]string{"maintenance window", want.MaintenanceWindow.Day + " " + want.MaintenanceWindow.Time, window})
+	}
+
+	if want.DBNames != nil {
+		ctx, op := i.databases.observer.begin(ctx, "Databases.ListDBs", slog.String("cluster_id", have.ID))
+		dbs, resp, err := i.databases.client.ListDBs(ctx, have.ID, nil)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to find all databases in cluster: " + have.ID + ". Godo error: " + err.Error())
+		}
+		var names []string
+		for _, db := range dbs {
+			names = append(names, db.Name)
+		}
+		fields = append(fields, [3]string{"databases", setString(want.DBNames), setString(names)})
+	}
+
+	return differences(databaseResource, want.Name, have.ID, fields), nil
+}
+
+// differences returns a Drift for each field, want and have triple that differ.
+func differences(resource string, name string, id string, fields [][3]string) []Drift {
+	var drifts []Drift
+	for _, f := range fields {
+		if f[1] != f[2] {
+			drifts = append(drifts, Drift{Resource: resource, Name: name, ID: id, Field: f[0], Want: f[1], Have: f[2]})
+		}
+	}
+	return drifts
+}
+
+// setString prints values sorted, so sets compare equal whatever their order.
+func setString(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,82 @@
+package dog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestDriftSpec = Spec{
+	Droplets: []CreateDropletRequest{
+		{Name: "test.example.com", Region: NYC1, DropletSize: S1Cpu1GbRAM, Tags: []string{"tag"}},
+		{Name: "web", Region: NYC3, DropletSize: S1Cpu2GbRAM},
+	},
+	Databases: []DatabaseClusterSpec{
+		{
+			CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{Name: "dbtest", DatabaseType: MySQL, DatabaseSize: DbS1Cpu1GbRAM10GbStorage, Region: SFO2, NumNodes: 3, Tags: []string{"staging", "production"}},
+			MaintenanceWindow:            &MaintenanceWindow{Day: "monday", Time: "13:51"},
+			DBNames:                      []string{"Test Database Name"},
+		},
+	},
+}
+
+func TestDetectDrift(t *testing.T) {
+
+	infra := newTestInfrastructure()
+
+	report, err := infra.DetectDrift(TestDriftSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &DriftReport{
+		Resources: 3,
+		Drifted:   3,
+		Drifts: []Drift{
+			{Resource: dropletResource, Name: "test.example.com", ID: "1", Field: "region", Want: "nyc1", Have: "none"},
+			{Resource: dropletResource, Name: "web", Field: "presence", Want: "present", Have: missing},
+			{Resource: databaseResource, Name: "dbtest", ID: ExpectedDB.ID, Field: "size", Want: "db-s-1vcpu-1gb", Have: "test size slug"},
+		},
+	}
+	if !reflect.DeepEqual(expected, report) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, report)
+	}
+
+	expectedText := "~ droplet test.example.com (1): region is none, want nyc1\n" +
+		"- droplet web: missing\n" +
+		"~ database cluster dbtest (TestID-2131241): size is test size slug, want db-s-1vcpu-1gb\n" +
+		"\nDrift: 3 differences in 3 of 3 resources.\n"
+	if returned := report.String(); expectedText != returned {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expectedText, returned)
+	}
+
+	clean, _ := infra.DetectDrift(Spec{Databases: []DatabaseClusterSpec{{CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{Name: "dbtest", DatabaseType: MySQL, Region: SFO2, NumNodes: 3, Tags: []string{"production", "staging"}}}}})
+	if len(clean.Drifts) != 1 || clean.Drifts[0].Field != "size" {
+		t.Errorf("expected only the size to drift, returned %+v", clean.Drifts)
+	}
+
+	// same-named clusters count as the one resource of the spec they match
+	twinCluster := ExpectedDB
+	twinCluster.ID = "TestID-2131242"
+	infra.databases.client = &MockGodoDatabaseSvc{listed: []godo.Database{ExpectedDB, twinCluster}}
+	twins, _ := infra.DetectDrift(Spec{Databases: TestDriftSpec.Databases})
+	if twins.Resources != 1 || twins.Drifted != 1 || len(twins.Drifts) != 2 {
+		t.Errorf("expected both clusters' drifts in 1 of 1 resources, returned %+v", twins)
+	}
+	infra.databases.client = &MockGodoDatabaseSvc{}
+
+	if (&DriftReport{Resources: 2}).Summary() != "No drift in 2 resources." {
+		t.Errorf("unexpected summary without drift")
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		spec := Spec{Databases: []DatabaseClusterSpec{{}}}
+		expectedError := "Every database cluster in the spec needs a name"
+		_, returnedError := infra.DetectDrift(spec)
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+	})
+
+}
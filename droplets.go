@@ -3,6 +3,7 @@ package dog
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"strconv"
 
 	"github.com/digitalocean/godo"
@@ -21,6 +22,12 @@ type CreateDropletRequest struct {
 	Volumes           []string
 	Tags              []string
 	VPCUUID           string
+	// Monitoring installs the agent that alert policies and droplet metrics need
+	Monitoring bool
+	// VPC is the name or UUID of a VPC in Region and takes precedence over VPCUUID
+	VPC string
+	// Project is the name or ID of the project the droplet is assigned to
+	Project string
 }
 
 type FindAllDropletsRequest struct {
@@ -42,6 +49,31 @@ type DeleteDropletRequest struct {
 	ID int
 }
 
+type FindDropletBackupsRequest struct {
+	ID      int
+	Page    int
+	PerPage int
+}
+
+type FindDropletSnapshotsRequest struct {
+	ID      int
+	Page    int
+	PerPage int
+}
+
+type FindDropletKernelsRequest struct {
+	ID      int
+	Page    int
+	PerPage int
+}
+
+// RestoreDropletRequest replaces the droplet's disk with the backup image
+// BackupID, which must be one of the droplet's own backups.
+type RestoreDropletRequest struct {
+	ID       int
+	BackupID int
+}
+
 // Droplet sizes
 type DropletSize int
 
@@ -85,21 +117,49 @@ func (ds DropletSize) String() string {
 	return names[ds]
 }
 
+// ParseDropletSize returns the droplet size with slug, e.g. "s-1vcpu-1gb".
+func ParseDropletSize(slug string) (DropletSize, error) {
+	i, err := parseSlug("droplet size", slug, int(S32Cpu19GbRAM)+1, func(i int) string { return DropletSize(i).String() })
+	return DropletSize(i), err
+}
+
 type DropletClient interface {
 	List(context.Context, *godo.ListOptions) ([]godo.Droplet, *godo.Response, error)
 	ListByTag(context.Context, string, *godo.ListOptions) ([]godo.Droplet, *godo.Response, error)
 	Get(context.Context, int) (*godo.Droplet, *godo.Response, error)
 	Create(context.Context, *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error)
 	Delete(context.Context, int) (*godo.Response, error)
+	Backups(context.Context, int, *godo.ListOptions) ([]godo.Image, *godo.Response, error)
+	Snapshots(context.Context, int, *godo.ListOptions) ([]godo.Image, *godo.Response, error)
+	Kernels(context.Context, int, *godo.ListOptions) ([]godo.Kernel, *godo.Response, error)
+	Neighbors(context.Context, int) ([]godo.Droplet, *godo.Response, error)
+}
+
+type DropletActionClient interface {
+	Restore(context.Context, int, int) (*godo.Action, *godo.Response, error)
 }
 
 type Droplet struct {
-	client DropletClient
+	client   DropletClient
+	actions  DropletActionClient
+	vpcs     VPCClient
+	projects ProjectClient
+	dryRun   *dryRun
+	observer observer
+}
+
+func NewDC(pat string, opts ...Option) Droplet {
+	client := Authenticate(pat, opts...)
+	options := newClientOptions(opts)
+	return Droplet{client: client.Droplets, actions: client.DropletActions, vpcs: client.VPCs, projects: client.Projects, dryRun: options.dryRun, observer: options.observer()}
 }
 
-func NewDC(pat string) Droplet {
-	client := Authenticate(pat)
-	return Droplet{client: client.Droplets}
+// NewDCWithClient builds a Droplet on client, e.g. a dogtest fake, instead of
+// the DigitalOcean API. VPC and project lookups and restores are unavailable.
+// Only WithDryRun, WithLogger and WithTelemetry of opts apply.
+func NewDCWithClient(client DropletClient, opts ...Option) Droplet {
+	options := newClientOptions(opts)
+	return Droplet{client: client, dryRun: options.dryRun, observer: options.observer()}
 }
 
 func (d *Droplet) GetAllDroplets(far FindAllDropletsRequest) ([]godo.Droplet, error) {
@@ -111,7 +171,9 @@ func (d *Droplet) GetAllDroplets(far FindAllDropletsRequest) ([]godo.Droplet, er
 
 	ctx := context.TODO()
 
-	droplets, _, err := d.client.List(ctx, opt)
+	ctx, op := d.observer.begin(ctx, "Droplets.List", slog.Int("page", far.Page))
+	droplets, resp, err := d.client.List(ctx, opt)
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to get all databases. Godo error: " + err.Error())
 	}
@@ -123,7 +185,12 @@ func (d *Droplet) GetDropletById(fdr FindDropletByIDRequest) (*godo.Droplet, err
 
 	ctx := context.TODO()
 
-	droplet, _, err := d.client.Get(ctx, fdr.ID)
+	ctx, op := d.observer.begin(ctx, "Droplets.Get", slog.Int("droplet_id", fdr.ID))
+	droplet, resp, err := d.client.Get(ctx, fdr.ID)
+	if droplet != nil && droplet.Region != nil {
+		op.add(slog.String("region", droplet.Region.Slug), slog.String("size", droplet.SizeSlug))
+	}
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Droplet with id: " + strconv.Itoa(fdr.ID) + ", was not found. Godo error: " + err.Error())
 	}
@@ -140,7 +207,9 @@ func (d *Droplet) GetDropletsByTag(fdr FindDropletsByTagRequest) (*[]godo.Drople
 		PerPage: fdr.PerPage,
 	}
 
-	droplets, _, err := d.client.ListByTag(ctx, fdr.Tag, opt)
+	ctx, op := d.observer.begin(ctx, "Droplets.ListByTag", slog.String("tag", fdr.Tag), slog.Int("page", fdr.Page))
+	droplets, resp, err := d.client.ListByTag(ctx, fdr.Tag, opt)
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Droplets with Tag: " + fdr.Tag + ", weer not found. Godo error: " + err.Error())
 	}
@@ -150,6 +219,12 @@ func (d *Droplet) GetDropletsByTag(fdr FindDropletsByTagRequest) (*[]godo.Drople
 
 func (d *Droplet) CreateDroplet(cdr CreateDropletRequest) (*godo.Droplet, error) {
 
+	if d.dryRun != nil {
+		if err := cdr.validate(); err != nil {
+			return nil, errors.New("Unable to create droplet. " + err.Error())
+		}
+	}
+
 	keys := createGodoSSHKeys(cdr.SSHKeys)
 	volumes := createVolumes(cdr.Volumes)
 
@@ -161,21 +236,60 @@ func (d *Droplet) CreateDroplet(cdr CreateDropletRequest) (*godo.Droplet, error)
 			Slug: cdr.Image,
 		},
 		SSHKeys:           keys,
+		Backups:           cdr.Backups,
 		IPv6:              cdr.IPv6,
 		UserData:          cdr.Configuration,
 		PrivateNetworking: cdr.PrivateNetworking,
 		Volumes:           volumes,
 		Tags:              cdr.Tags,
 		VPCUUID:           cdr.VPCUUID,
+		Monitoring:        cdr.Monitoring,
 	}
 
 	ctx := context.TODO()
 
-	droplet, _, err := d.client.Create(ctx, create)
+	if cdr.VPC != "" {
+		vpc, err := resolveVPC(ctx, d.observer, d.vpcs, cdr.VPC, cdr.Region)
+		if err != nil {
+			return nil, errors.New("Unable to create droplet. " + err.Error())
+		}
+		create.VPCUUID = vpc.ID
+	}
+
+	var project *godo.Project
+	if cdr.Project != "" {
+		found, err := findProject(ctx, d.observer, d.projects, cdr.Project)
+		if err != nil {
+			return nil, errors.New("Unable to create droplet. " + err.Error())
+		}
+		project = found
+	}
+
+	if d.dryRun != nil {
+		d.dryRun.record("Droplets.Create", create)
+		if project != nil {
+			d.dryRun.record("Projects.AssignResources", project.ID)
+		}
+		return d.dryRun.droplet(create), nil
+	}
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Create", slog.String("region", create.Region), slog.String("size", create.Size), logRequest(create))
+	droplet, resp, err := d.client.Create(ctx, create)
+	if droplet != nil {
+		op.add(slog.Int("droplet_id", droplet.ID))
+	}
+	op.end(resp, err)
 	if err != nil {
 		return nil, errors.New("Unable to create droplet. Godo error: " + err.Error())
 	}
 
+	// the droplet exists at this point, so it is returned even if assignment fails
+	if project != nil {
+		if _, err := assignToProject(ctx, d.observer, d.projects, project.ID, droplet.URN()); err != nil {
+			return droplet, errors.New("Droplet with id: " + strconv.Itoa(droplet.ID) + " was created but not assigned to project. " + err.Error())
+		}
+	}
+
 	return droplet, nil
 }
 
@@ -183,17 +297,144 @@ func(d *Droplet) DeleteDroplet(ddr DeleteDropletRequest) error {
 
 	ctx := context.TODO()
 
-	_, err := d.client.Delete(ctx, ddr.ID)
+	if d.dryRun != nil {
+		if ddr.ID < 1 {
+			return errors.New("Unable to delete droplet with ID: " + strconv.Itoa(ddr.ID) + ". It is not a droplet ID")
+		}
+		d.dryRun.record("Droplets.Delete", ddr.ID)
+		return nil
+	}
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Delete", slog.Int("droplet_id", ddr.ID))
+	resp, err := d.client.Delete(ctx, ddr.ID)
+	op.end(resp, err)
 	if err != nil {
 		return errors.New("Unable to delete droplet with ID: " + strconv.Itoa(ddr.ID))
 	}
 	return nil
 }
 
+func (d *Droplet) ListBackups(fdbr FindDropletBackupsRequest) ([]godo.Image, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fdbr.Page,
+		PerPage: fdbr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Backups", slog.Int("droplet_id", fdbr.ID))
+	backups, resp, err := d.client.Backups(ctx, fdbr.ID, opt)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to get backups of droplet with id: " + strconv.Itoa(fdbr.ID) + ". Godo error: " + err.Error())
+	}
+
+	return backups, nil
+}
+
+func (d *Droplet) ListSnapshots(fdsr FindDropletSnapshotsRequest) ([]godo.Image, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fdsr.Page,
+		PerPage: fdsr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Snapshots", slog.Int("droplet_id", fdsr.ID))
+	snapshots, resp, err := d.client.Snapshots(ctx, fdsr.ID, opt)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to get snapshots of droplet with id: " + strconv.Itoa(fdsr.ID) + ". Godo error: " + err.Error())
+	}
+
+	return snapshots, nil
+}
+
+func (d *Droplet) ListKernels(fdkr FindDropletKernelsRequest) ([]godo.Kernel, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fdkr.Page,
+		PerPage: fdkr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Kernels", slog.Int("droplet_id", fdkr.ID))
+	kernels, resp, err := d.client.Kernels(ctx, fdkr.ID, opt)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to get kernels of droplet with id: " + strconv.Itoa(fdkr.ID) + ". Godo error: " + err.Error())
+	}
+
+	return kernels, nil
+}
+
+// ListNeighbors returns the droplets running on the same physical host.
+func (d *Droplet) ListNeighbors(id int) ([]godo.Droplet, error) {
+
+	ctx := context.TODO()
+
+	ctx, op := d.observer.begin(ctx, "Droplets.Neighbors", slog.Int("droplet_id", id))
+	neighbors, resp, err := d.client.Neighbors(ctx, id)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to get neighbors of droplet with id: " + strconv.Itoa(id) + ". Godo error: " + err.Error())
+	}
+
+	return neighbors, nil
+}
+
+// RestoreFromBackup starts restoring the droplet and returns the action
+// without waiting for it to finish.
+func (d *Droplet) RestoreFromBackup(rdr RestoreDropletRequest) (*godo.Action, error) {
+
+	if d.actions == nil {
+		return nil, errors.New("Unable to restore droplet with id: " + strconv.Itoa(rdr.ID) + ". Droplet actions are unavailable on this client")
+	}
+
+	ctx := context.TODO()
+
+	found := false
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for !found {
+		pageCtx, op := d.observer.begin(ctx, "Droplets.Backups", slog.Int("droplet_id", rdr.ID), slog.Int("page", opt.Page))
+		backups, resp, err := d.client.Backups(pageCtx, rdr.ID, opt)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to get backups of droplet with id: " + strconv.Itoa(rdr.ID) + ". Godo error: " + err.Error())
+		}
+
+		for _, backup := range backups {
+			if backup.ID == rdr.BackupID {
+				found = true
+			}
+		}
+
+		if isLastPage(resp) {
+			break
+		}
+		opt.Page++
+	}
+	if !found {
+		return nil, errors.New("Unable to restore droplet with id: " + strconv.Itoa(rdr.ID) + ". Backup with id: " + strconv.Itoa(rdr.BackupID) + " is not one of its backups")
+	}
+
+	ctx, op := d.observer.begin(ctx, "DropletActions.Restore", slog.Int("droplet_id", rdr.ID), slog.Int("backup_id", rdr.BackupID))
+	action, resp, err := d.actions.Restore(ctx, rdr.ID, rdr.BackupID)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to restore droplet with id: " + strconv.Itoa(rdr.ID) + ". Godo error: " + err.Error())
+	}
+
+	return action, nil
+}
+
 func createGodoSSHKeys(keys []int) []godo.DropletCreateSSHKey {
 	var godoKeys []godo.DropletCreateSSHKey
 
-	for i := 1; i < len(keys); i++ {
+	for i := 0; i < len(keys); i++ {
 		key := godo.DropletCreateSSHKey{ID: keys[i]}
 		godoKeys = append(godoKeys, key)
 	}
@@ -203,7 +444,7 @@ func createGodoSSHKeys(keys []int) []godo.DropletCreateSSHKey {
 func createVolumes(volumes []string) []godo.DropletCreateVolume {
 	var godoVolumes []godo.DropletCreateVolume
 
-	for i := 1; i < len(volumes); i++ {
+	for i := 0; i < len(volumes); i++ {
 		volume := godo.DropletCreateVolume{ID: volumes[i]}
 		godoVolumes = append(godoVolumes, volume)
 	}
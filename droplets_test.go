@@ -72,6 +72,27 @@ var TestDeleteDropletRequest = DeleteDropletRequest{
 	ID: 1,
 }
 
+var TestBackups = []godo.Image{
+	{ID: 1, Name: "test.example.com 2020-01-01", Type: "backup"},
+	{ID: 2, Name: "test.example.com 2020-01-08", Type: "backup"},
+	{ID: 3, Name: "test.example.com 2020-01-15", Type: "backup"},
+	{ID: 4, Name: "test.example.com 2020-01-22", Type: "backup"},
+}
+
+var TestSnapshots = []godo.Image{
+	{ID: 1, Name: "before-upgrade", Type: "snapshot"},
+}
+
+var TestKernels = []godo.Kernel{*TestDroplet.Kernel}
+
+var TestRestoreAction = godo.Action{
+	ID:           36805022,
+	Status:       "in-progress",
+	Type:         "restore",
+	ResourceID:   1,
+	ResourceType: "droplet",
+}
+
 func TestGetAllDroplets(t *testing.T) {
 
 	dbClient := NewDC(TestPAT)
@@ -124,6 +145,88 @@ func TestCreateDroplet(t *testing.T) {
 
 }
 
+func TestCreateDropletRequestSent(t *testing.T) {
+
+	svc := &MockGodoDropletSvc{}
+	dbClient := NewDC(TestPAT)
+	dbClient.client = svc
+
+	if _, err := dbClient.CreateDroplet(TestCreateDropletRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &godo.DropletCreateRequest{
+		Name:              "Droplet Name",
+		Region:            "nyc2",
+		Size:              "s-3vcpu-1gb",
+		Image:             godo.DropletCreateImage{Slug: "Test Image"},
+		SSHKeys:           []godo.DropletCreateSSHKey{{ID: 1}, {ID: 2}, {ID: 3}},
+		Backups:           true,
+		IPv6:              false,
+		UserData:          "Test Config",
+		PrivateNetworking: false,
+		Volumes:           []godo.DropletCreateVolume{{ID: "test"}, {ID: "volumes"}},
+		Tags:              []string{"dog"},
+		VPCUUID:           "ASD-342",
+	}
+	if !reflect.DeepEqual(expected, svc.created) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, svc.created)
+	}
+
+}
+
+func TestCreateDropletInVPC(t *testing.T) {
+
+	t.Run("VPC is resolved by name", func(t *testing.T) {
+		dbClient := NewDC(TestPAT)
+		dbClient.client = &MockGodoDropletSvc{}
+		dbClient.vpcs = &MockGodoVPCSvc{}
+
+		request := TestCreateDropletRequest
+		request.VPC = TestVPC.Name
+		expected := &TestDroplet
+		returned, _ := dbClient.CreateDroplet(request)
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+		}
+	})
+
+	t.Run("Error is thrown when VPC is in another region", func(t *testing.T) {
+		dbClient := NewDC(TestPAT)
+		dbClient.client = &MockGodoDropletSvc{}
+		dbClient.vpcs = &MockGodoVPCSvc{}
+
+		request := TestCreateDropletRequest
+		request.Region = SFO2
+		request.VPC = TestVPC.Name
+		expectedError := "Unable to create droplet. VPC: " + TestVPC.Name + " is in nyc2, not sfo2"
+		_, returnedError := dbClient.CreateDroplet(request)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestCreateDropletInProject(t *testing.T) {
+
+	dbClient := NewDC(TestPAT)
+	dbClient.client = &MockGodoDropletSvc{}
+	dbClient.projects = &MockGodoProjectSvc{}
+
+	request := TestCreateDropletRequest
+	request.Project = TestProject.Name
+	expected := &TestDroplet
+	returned, err := dbClient.CreateDroplet(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
 func TestDeleteDroplet(t *testing.T) {
 
 	t.Run("Error is thrown", func(t *testing.T) {
@@ -139,9 +242,91 @@ func TestDeleteDroplet(t *testing.T) {
 
 }
 
-type MockGodoDropletSvc struct{}
+func TestListBackups(t *testing.T) {
+
+	dbClient := NewDC(TestPAT)
+	dbClient.client = &MockGodoDropletSvc{}
+
+	expected := TestBackups
+	returned, _ := dbClient.ListBackups(FindDropletBackupsRequest{ID: TestDroplet.ID, Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestListSnapshots(t *testing.T) {
+
+	dbClient := NewDC(TestPAT)
+	dbClient.client = &MockGodoDropletSvc{}
+
+	expected := TestSnapshots
+	returned, _ := dbClient.ListSnapshots(FindDropletSnapshotsRequest{ID: TestDroplet.ID, Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestListKernels(t *testing.T) {
+
+	dbClient := NewDC(TestPAT)
+	dbClient.client = &MockGodoDropletSvc{}
+
+	expected := TestKernels
+	returned, _ := dbClient.ListKernels(FindDropletKernelsRequest{ID: TestDroplet.ID, Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestListNeighbors(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		dbClient := NewDC(TestPAT)
+		dbClient.client = &MockGodoDropletSvc{}
+
+		expectedError := "Unable to get neighbors of droplet with id: 1. Godo error: " + TestError
+		_, returnedError := dbClient.ListNeighbors(TestDroplet.ID)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestRestoreFromBackup(t *testing.T) {
+
+	dbClient := NewDC(TestPAT)
+	dbClient.client = &MockGodoDropletSvc{}
+	dbClient.actions = &MockGodoDropletActionSvc{}
+
+	expected := &TestRestoreAction
+	returned, _ := dbClient.RestoreFromBackup(RestoreDropletRequest{ID: TestDroplet.ID, BackupID: 3})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Unable to restore droplet with id: 1. Backup with id: 9 is not one of its backups"
+		_, returnedError := dbClient.RestoreFromBackup(RestoreDropletRequest{ID: TestDroplet.ID, BackupID: 9})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoDropletSvc struct {
+	created *godo.DropletCreateRequest
+	listed  []godo.Droplet
+}
 
 func (m *MockGodoDropletSvc) List(context.Context, *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	if m.listed != nil {
+		return m.listed, nil, nil
+	}
 	return TestDroplets, nil, nil
 }
 
@@ -153,10 +338,33 @@ func (m *MockGodoDropletSvc) Get(context.Context, int) (*godo.Droplet, *godo.Res
 	return &TestDroplet, nil, nil
 }
 
-func (m *MockGodoDropletSvc) Create(context.Context, *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error) {
+func (m *MockGodoDropletSvc) Create(ctx context.Context, create *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error) {
+	m.created = create
 	return &TestDroplet, nil, nil
 }
 
 func (m *MockGodoDropletSvc) Delete(context.Context, int) (*godo.Response, error) {
 	return nil, errors.New("Test Godo Error")
 }
+
+func (m *MockGodoDropletSvc) Backups(context.Context, int, *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	return TestBackups, nil, nil
+}
+
+func (m *MockGodoDropletSvc) Snapshots(context.Context, int, *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	return TestSnapshots, nil, nil
+}
+
+func (m *MockGodoDropletSvc) Kernels(context.Context, int, *godo.ListOptions) ([]godo.Kernel, *godo.Response, error) {
+	return TestKernels, nil, nil
+}
+
+func (m *MockGodoDropletSvc) Neighbors(context.Context, int) ([]godo.Droplet, *godo.Response, error) {
+	return nil, nil, errors.New(TestError)
+}
+
+type MockGodoDropletActionSvc struct{}
+
+func (m *MockGodoDropletActionSvc) Restore(context.Context, int, int) (*godo.Action, *godo.Response, error) {
+	return &TestRestoreAction, nil, nil
+}
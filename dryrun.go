@@ -0,0 +1,223 @@
+package dog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// WithDryRun stops droplet and database clients from changing anything.
+// Their mutating methods validate their input, write the godo call they would
+// make to log, or to stderr when log is nil, and return synthesized results.
+// Lookups, such as of the VPC a droplet is created in, still reach the API.
+// Clients built with the same option share the log and synthesized IDs.
+func WithDryRun(log io.Writer) Option {
+	if log == nil {
+		log = os.Stderr
+	}
+	dr := &dryRun{log: log}
+	return func(o *clientOptions) {
+		o.dryRun = dr
+	}
+}
+
+type dryRun struct {
+	mu     sync.Mutex
+	log    io.Writer
+	lastID int
+}
+
+// record writes a line per call, with secrets in the request redacted, e.g.
+//
+//	dry run: Databases.Resize 9cc10173 {"num_nodes":2,"size":"db-s-2vcpu-4gb"}
+func (dr *dryRun) record(call string, args ...interface{}) {
+	line := "dry run: " + call
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			line += " " + a
+		case int:
+			line += " " + strconv.Itoa(a)
+		default:
+			body, err := redactRequest(a)
+			if err != nil {
+				line += " " + redacted
+				continue
+			}
+			encoded, _ := json.Marshal(body)
+			line += " " + string(encoded)
+		}
+	}
+
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	fmt.Fprintln(dr.log, line)
+}
+
+// redactRequest decodes the JSON body of a godo request and redacts it.
+func redactRequest(request interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var body interface{}
+	if err := json.Unmarshal(encoded, &body); err != nil {
+		return nil, err
+	}
+	return redact(body), nil
+}
+
+// nextID returns a placeholder ID for a resource that was not created, so
+// calls chained on it can be dry run too.
+func (dr *dryRun) nextID() int {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	dr.lastID++
+	return dr.lastID
+}
+
+func (dr *dryRun) clusterID() string {
+	return "dry-run-" + strconv.Itoa(dr.nextID())
+}
+
+func (dr *dryRun) droplet(create *godo.DropletCreateRequest) *godo.Droplet {
+	droplet := &godo.Droplet{
+		ID:       dr.nextID(),
+		Name:     create.Name,
+		Status:   "new",
+		Region:   &godo.Region{Slug: create.Region},
+		SizeSlug: create.Size,
+		Size:     &godo.Size{Slug: create.Size},
+		Image:    &godo.Image{Slug: create.Image.Slug},
+		Tags:     create.Tags,
+		VPCUUID:  create.VPCUUID,
+		Created:  time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, volume := range create.Volumes {
+		droplet.VolumeIDs = append(droplet.VolumeIDs, volume.ID)
+	}
+	return droplet
+}
+
+func (dr *dryRun) cluster(create *godo.DatabaseCreateRequest) *godo.Database {
+	cluster := &godo.Database{
+		ID:                 dr.clusterID(),
+		Name:               create.Name,
+		EngineSlug:         create.EngineSlug,
+		VersionSlug:        create.Version,
+		SizeSlug:           create.SizeSlug,
+		RegionSlug:         create.Region,
+		NumNodes:           create.NumNodes,
+		Tags:               create.Tags,
+		PrivateNetworkUUID: create.PrivateNetworkUUID,
+		Status:             "creating",
+		CreatedAt:          time.Now().UTC(),
+	}
+	if create.EngineSlug != Redis.String() {
+		cluster.DBNames = []string{"defaultdb"}
+	}
+	return cluster
+}
+
+// Validation of the input the API would otherwise have rejected
+
+func (cdr CreateDropletRequest) validate() error {
+	if cdr.Name == "" {
+		return errors.New("A droplet name is required")
+	}
+	if cdr.Region < NYC1 || cdr.Region > BLR1 {
+		return errors.New(strconv.Itoa(int(cdr.Region)) + " is not a region")
+	}
+	if cdr.DropletSize < S1Cpu1GbRAM || cdr.DropletSize > S32Cpu19GbRAM {
+		return errors.New(strconv.Itoa(int(cdr.DropletSize)) + " is not a droplet size")
+	}
+	if cdr.Image == "" {
+		return errors.New("An image is required")
+	}
+	return nil
+}
+
+func (cdcr CreateDatabaseClusterRequest) validate() error {
+	if cdcr.Name == "" {
+		return errors.New("A database cluster name is required")
+	}
+	if cdcr.DatabaseType < PostGres || cdcr.DatabaseType > MySQL {
+		return errors.New(strconv.Itoa(int(cdcr.DatabaseType)) + " is not a database type")
+	}
+	if err := validateClusterSize(cdcr.DatabaseSize, cdcr.NumNodes); err != nil {
+		return err
+	}
+	if cdcr.Region < NYC1 || cdcr.Region > BLR1 {
+		return errors.New(strconv.Itoa(int(cdcr.Region)) + " is not a region")
+	}
+	return nil
+}
+
+func (rcr ResizeClusterRequest) validate() error {
+	if rcr.Id == "" {
+		return errors.New("A database cluster ID is required")
+	}
+	return validateClusterSize(rcr.DatabaseSize, rcr.NumNodes)
+}
+
+func (mrr MigrateRegionRequest) validate() error {
+	if mrr.Id == "" {
+		return errors.New("A database cluster ID is required")
+	}
+	if mrr.Region < NYC1 || mrr.Region > BLR1 {
+		return errors.New(strconv.Itoa(int(mrr.Region)) + " is not a region")
+	}
+	return nil
+}
+
+func (umw UpdateMaintenanceWindowRequest) validate() error {
+	if umw.Id == "" {
+		return errors.New("A database cluster ID is required")
+	}
+	days := []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	if !hasString(days, strings.ToLower(umw.Day)) {
+		return errors.New(umw.Day + " is not a day of the week")
+	}
+	if _, err := time.Parse("15:04", umw.Time); err != nil {
+		return errors.New(umw.Time + " is not a time of day such as 14:00")
+	}
+	return nil
+}
+
+func (cdb CreateDatabaseRequest) validate() error {
+	if cdb.ClusterID == "" {
+		return errors.New("A database cluster ID is required")
+	}
+	if cdb.Name == "" {
+		return errors.New("A database name is required")
+	}
+	return nil
+}
+
+func (dr DeleteDatabaseRequest) validate() error {
+	if dr.ClusterID == "" {
+		return errors.New("A database cluster ID is required")
+	}
+	if dr.Name == "" {
+		return errors.New("A database name is required")
+	}
+	return nil
+}
+
+func validateClusterSize(size DatabaseSize, nodes int) error {
+	if size < DbS1Cpu1GbRAM10GbStorage || size > DbS16Cpu64GbRAM1120GbStorage {
+		return errors.New(strconv.Itoa(int(size)) + " is not a database size")
+	}
+	if nodes < 1 {
+		return errors.New("A database cluster needs at least 1 node")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package dog
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+func TestDryRunDroplets(t *testing.T) {
+
+	var log bytes.Buffer
+	dClient := NewDCWithClient(&MockGodoDropletSvc{}, WithDryRun(&log))
+
+	droplet, err := dClient.CreateDroplet(TestCreateDropletRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if droplet.ID != 1 || droplet.Status != "new" || droplet.Name != "Droplet Name" || droplet.SizeSlug != "s-3vcpu-1gb" {
+		t.Errorf("expected a synthesized droplet, returned %+v", droplet)
+	}
+
+	// the mock fails deletes, so success means nothing was sent
+	if err := dClient.DeleteDroplet(DeleteDropletRequest{ID: droplet.ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `dry run: Droplets.Create {"backups":true,"image":"Test Image","ipv6":false,"monitoring":false,"name":"Droplet Name","private_networking":false,"region":"nyc2","size":"s-3vcpu-1gb","ssh_keys":[1,2,3],"tags":["dog"],"user_data":"REDACTED","volumes":[{"id":"test"},{"id":"volumes"}],"vpc_uuid":"ASD-342"}
+dry run: Droplets.Delete 1
+`
+	if expected != log.String() {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, log.String())
+	}
+	if strings.Contains(log.String(), TestCreateDropletRequest.Configuration) {
+		t.Errorf("expected user data to be redacted, returned %s", log.String())
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		invalid := TestCreateDropletRequest
+		invalid.DropletSize = 99
+		if _, err := dClient.CreateDroplet(invalid); err == nil {
+			t.Errorf("expected an invalid size to be rejected")
+		}
+		if err := dClient.DeleteDroplet(DeleteDropletRequest{}); err == nil {
+			t.Errorf("expected a missing ID to be rejected")
+		}
+	})
+
+}
+
+func TestDryRunDatabases(t *testing.T) {
+
+	var log bytes.Buffer
+	dbClient := NewDBCWithClient(&MockGodoDatabaseSvc{}, WithDryRun(&log))
+
+	cluster, err := dbClient.Create(TestCreateDatabaseClusterRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cluster.ID != "dry-run-1" || cluster.Status != "creating" || !reflect.DeepEqual(cluster.DBNames, []string{"defaultdb"}) {
+		t.Errorf("expected a synthesized cluster, returned %+v", cluster)
+	}
+
+	// the mock fails each of these, so success means nothing was sent
+	if err := dbClient.ResizeCluster(TestResizeClusterRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := dbClient.MigrateToNewRegion(TestMigrateNewRegionRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := dbClient.ConfigureMaintenanceWindow(TestUpdateMaintenanceWindowRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	database, err := dbClient.AddDatabaseToCluster(TestCreateDatabaseRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(&godo.DatabaseDB{Name: "Test Database Name"}, database) {
+		t.Errorf("expected a synthesized database, returned %+v", database)
+	}
+	if err := dbClient.DeleteDatabaseInCluster(TestDeleteDatabaseRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := dbClient.DeleteCluster(cluster.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the create body varies with the godo version, so only its fields are compared
+	lines := strings.SplitN(log.String(), "\n", 2)
+	var create map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[0], "dry run: Databases.Create ")), &create); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for key, value := range map[string]interface{}{"name": "Test Request", "engine": "mysql", "size": "db-s-1vcpu-1gb", "region": "fra1", "num_nodes": 1.0} {
+		if create[key] != value {
+			t.Errorf("expected %s to be %+v\n , returned, %+v\n ", key, value, create[key])
+		}
+	}
+
+	expected := `dry run: Databases.Resize 1 {"num_nodes":1,"size":"db-s-1vcpu-2gb"}
+dry run: Databases.Migrate 1 {"private_network_uuid":"","region":"nyc2"}
+dry run: Databases.UpdateMaintenance 1 {"day":"Tuesday","hour":"18:00"}
+dry run: Databases.CreateDB 234234-1234BC24 {"name":"Test Database Name"}
+dry run: Databases.DeleteDB 123-4567 Test Database Name
+dry run: Databases.Delete dry-run-1
+`
+	if expected != lines[1] {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, lines[1])
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		if err := dbClient.ResizeCluster(ResizeClusterRequest{Id: "1", DatabaseSize: DbS1Cpu1GbRAM10GbStorage}); err == nil {
+			t.Errorf("expected a cluster without nodes to be rejected")
+		}
+		if err := dbClient.ConfigureMaintenanceWindow(UpdateMaintenanceWindowRequest{Id: "1", Day: "someday", Time: "18:00"}); err == nil {
+			t.Errorf("expected an unknown day to be rejected")
+		}
+		if err := dbClient.ConfigureMaintenanceWindow(UpdateMaintenanceWindowRequest{Id: "1", Day: "tuesday", Time: "6pm"}); err == nil {
+			t.Errorf("expected an unknown time to be rejected")
+		}
+		if _, err := dbClient.AddDatabaseToCluster(CreateDatabaseRequest{Name: "reports"}); err == nil {
+			t.Errorf("expected a missing cluster ID to be rejected")
+		}
+	})
+
+}
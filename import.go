@@ -0,0 +1,255 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// ImportRequest selects the live droplets and database clusters to import.
+// A resource is selected when its ID is listed, or when it has Tag and its
+// name matches NamePattern, a path.Match glob such as "web-*", ignoring
+// whichever of the two is empty. An empty request selects everything.
+type ImportRequest struct {
+	Tag         string
+	NamePattern string
+	DropletIDs  []int
+	ClusterIDs  []string
+}
+
+// ImportResult is the spec of the imported resources. Resources that a spec
+// cannot describe, such as droplets of sizes dog has no DropletSize for, are
+// listed in Skipped instead, as are resources that share a name and IDs that
+// match nothing.
+type ImportResult struct {
+	Spec
+	Skipped []SkippedResource
+}
+
+type SkippedResource struct {
+	Resource string
+	Name     string
+	ID       string
+	Reason   string
+}
+
+// Import converts the selected droplets and database clusters into a spec
+// that plans no changes against them, ready to be saved with WriteSpec. SSH
+// keys and user data cannot be read back from the API and are left empty.
+func (i *Infrastructure) Import(ir ImportRequest) (*ImportResult, error) {
+
+	ctx := context.TODO()
+
+	if _, err := path.Match(ir.NamePattern, ""); err != nil {
+		return nil, errors.New("Invalid name pattern " + ir.NamePattern + ". " + err.Error())
+	}
+
+	droplets, err := i.allDroplets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := i.allClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	selectIDs := len(ir.DropletIDs) > 0 || len(ir.ClusterIDs) > 0
+	selectAll := !selectIDs && ir.Tag == "" && ir.NamePattern == ""
+
+	var selected []godo.Droplet
+	listedDroplets := map[int]bool{}
+	for _, droplet := range droplets {
+		listed := false
+		for _, id := range ir.DropletIDs {
+			listed = listed || id == droplet.ID
+		}
+		if !selectAll && !listed && !ir.matches(droplet.Name, droplet.Tags, selectIDs) {
+			continue
+		}
+		listedDroplets[droplet.ID] = true
+		selected = append(selected, droplet)
+	}
+
+	// a spec tells resources apart by name, so resources sharing one cannot be imported
+	named := map[string]int{}
+	for _, droplet := range selected {
+		named[droplet.Name]++
+	}
+	for _, droplet := range selected {
+		if named[droplet.Name] > 1 {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: dropletResource, Name: droplet.Name, ID: strconv.Itoa(droplet.ID), Reason: "More than one droplet is named " + droplet.Name})
+			continue
+		}
+		request, err := importDroplet(droplet)
+		if err != nil {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: dropletResource, Name: droplet.Name, ID: strconv.Itoa(droplet.ID), Reason: err.Error()})
+			continue
+		}
+		result.Droplets = append(result.Droplets, request)
+	}
+	for _, id := range ir.DropletIDs {
+		if !listedDroplets[id] {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: dropletResource, ID: strconv.Itoa(id), Reason: "No droplet has this ID"})
+		}
+	}
+
+	var selectedClusters []godo.Database
+	listedClusters := map[string]bool{}
+	for _, cluster := range clusters {
+		listed := false
+		for _, id := range ir.ClusterIDs {
+			listed = listed || id == cluster.ID
+		}
+		if !selectAll && !listed && !ir.matches(cluster.Name, cluster.Tags, selectIDs) {
+			continue
+		}
+		listedClusters[cluster.ID] = true
+		selectedClusters = append(selectedClusters, cluster)
+	}
+
+	named = map[string]int{}
+	for _, cluster := range selectedClusters {
+		named[cluster.Name]++
+	}
+	for _, cluster := range selectedClusters {
+		if named[cluster.Name] > 1 {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: databaseResource, Name: cluster.Name, ID: cluster.ID, Reason: "More than one database cluster is named " + cluster.Name})
+			continue
+		}
+		spec, err := i.importCluster(ctx, cluster)
+		if err != nil {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: databaseResource, Name: cluster.Name, ID: cluster.ID, Reason: err.Error()})
+			continue
+		}
+		result.Databases = append(result.Databases, spec)
+	}
+	for _, id := range ir.ClusterIDs {
+		if !listedClusters[id] {
+			result.Skipped = append(result.Skipped, SkippedResource{Resource: databaseResource, ID: id, Reason: "No database cluster has this ID"})
+		}
+	}
+
+	if err := validateSpec(result.Spec); err != nil {
+		return nil, errors.New("Unable to import resources. " + err.Error())
+	}
+
+	return result, nil
+}
+
+// matches selects by tag and name pattern. When only IDs were given, only
+// the listed resources are selected.
+func (ir ImportRequest) matches(name string, tags []string, selectIDs bool) bool {
+	if ir.Tag == "" && ir.NamePattern == "" {
+		return !selectIDs
+	}
+	if ir.Tag != "" && !hasString(tags, ir.Tag) {
+		return false
+	}
+	if ir.NamePattern != "" {
+		if ok, _ := path.Match(ir.NamePattern, name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func importDroplet(droplet godo.Droplet) (CreateDropletRequest, error) {
+
+	region := ""
+	if droplet.Region != nil {
+		region = droplet.Region.Slug
+	}
+	r, err := ParseRegion(region)
+	if err != nil {
+		return CreateDropletRequest{}, err
+	}
+	size, err := ParseDropletSize(droplet.SizeSlug)
+	if err != nil {
+		return CreateDropletRequest{}, err
+	}
+	if droplet.Image == nil || droplet.Image.Slug == "" {
+		return CreateDropletRequest{}, errors.New("The droplet's image has no slug")
+	}
+
+	return CreateDropletRequest{
+		Name:              droplet.Name,
+		Region:            r,
+		DropletSize:       size,
+		Image:             droplet.Image.Slug,
+		Backups:           hasString(droplet.Features, "backups"),
+		IPv6:              hasString(droplet.Features, "ipv6"),
+		PrivateNetworking: hasString(droplet.Features, "private_networking"),
+		Monitoring:        hasString(droplet.Features, "monitoring"),
+		Volumes:           droplet.VolumeIDs,
+		Tags:              droplet.Tags,
+		VPCUUID:           droplet.VPCUUID,
+	}, nil
+}
+
+func (i *Infrastructure) importCluster(ctx context.Context, cluster godo.Database) (DatabaseClusterSpec, error) {
+
+	engine, err := ParseDatabaseType(cluster.EngineSlug)
+	if err != nil {
+		return DatabaseClusterSpec{}, err
+	}
+	size, err := ParseDatabaseSize(cluster.SizeSlug)
+	if err != nil {
+		return DatabaseClusterSpec{}, err
+	}
+	region, err := ParseRegion(cluster.RegionSlug)
+	if err != nil {
+		return DatabaseClusterSpec{}, err
+	}
+
+	spec := DatabaseClusterSpec{
+		CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{
+			Name:         cluster.Name,
+			DatabaseType: engine,
+			Version:      cluster.VersionSlug,
+			DatabaseSize: size,
+			Region:       region,
+			NumNodes:     cluster.NumNodes,
+			Tags:         cluster.Tags,
+			VPC:          cluster.PrivateNetworkUUID,
+		},
+	}
+
+	// windows are written to the minute, which is what ConfigureMaintenanceWindow takes
+	if window := cluster.MaintenanceWindow; window != nil {
+		hour := window.Hour
+		if parts := strings.Split(hour, ":"); len(parts) == 3 {
+			hour = parts[0] + ":" + parts[1]
+		}
+		spec.MaintenanceWindow = &MaintenanceWindow{Day: window.Day, Time: hour}
+	}
+
+	// Redis clusters hold no databases
+	if engine != Redis {
+		ctx, op := i.databases.observer.begin(ctx, "Databases.ListDBs", slog.String("cluster_id", cluster.ID))
+		dbs, resp, err := i.databases.client.ListDBs(ctx, cluster.ID, nil)
+		op.end(resp, err)
+		if err != nil {
+			return DatabaseClusterSpec{}, errors.New("Unable to find all databases in cluster: " + cluster.ID + ". Godo error: " + err.Error())
+		}
+		for _, db := range dbs {
+			spec.DBNames = append(spec.DBNames, db.Name)
+		}
+	}
+
+	return spec, nil
+}
+
+func hasString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package dog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestImportedDroplet = godo.Droplet{
+	ID:        7,
+	Name:      "web-1",
+	Region:    &godo.Region{Slug: "nyc3"},
+	SizeSlug:  "s-1vcpu-2gb",
+	Image:     &godo.Image{Slug: "ubuntu-22-04-x64"},
+	Features:  []string{"backups", "monitoring"},
+	VolumeIDs: []string{"vol-1"},
+	Tags:      []string{"web"},
+	VPCUUID:   "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+}
+
+func TestImport(t *testing.T) {
+
+	infra := newTestInfrastructure()
+
+	result, err := infra.Import(ImportRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []SkippedResource{
+		{Resource: dropletResource, Name: "test.example.com", ID: "1", Reason: " is not a region. Use one of nyc1, nyc2, nyc3, ams2, ams3, sfo1, sfo2, sgp1, lon1, fra1, tor1, blr1"},
+		{Resource: databaseResource, Name: "dbtest", ID: ExpectedDB.ID, Reason: "test size slug is not a database size. Use one of db-s-1vcpu-1gb, db-s-1vcpu-2gb, db-s-2vcpu-4gb, db-s-4vcpu-8gb, db-s-6vcpu-16gb, db-s-8vcpu-32gb, db-s-16vcpu-64gb"},
+	}
+	if !reflect.DeepEqual(expected, result.Skipped) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, result.Skipped)
+	}
+
+	selected, _ := infra.Import(ImportRequest{Tag: "tag", NamePattern: "db*"})
+	if len(selected.Skipped) != 0 {
+		t.Errorf("expected nothing to match both the tag and pattern, returned %+v", selected.Skipped)
+	}
+	selected, _ = infra.Import(ImportRequest{ClusterIDs: []string{ExpectedDB.ID}})
+	if len(selected.Skipped) != 1 || selected.Skipped[0].Name != "dbtest" {
+		t.Errorf("expected only the listed cluster, returned %+v", selected.Skipped)
+	}
+
+	selected, _ = infra.Import(ImportRequest{DropletIDs: []int{42}, ClusterIDs: []string{"missing"}})
+	expected = []SkippedResource{
+		{Resource: dropletResource, ID: "42", Reason: "No droplet has this ID"},
+		{Resource: databaseResource, ID: "missing", Reason: "No database cluster has this ID"},
+	}
+	if !reflect.DeepEqual(expected, selected.Skipped) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, selected.Skipped)
+	}
+
+	twin := TestImportedDroplet
+	twin.ID = 8
+	infra.droplets.client = &MockGodoDropletSvc{listed: []godo.Droplet{TestImportedDroplet, twin}}
+	selected, err = infra.Import(ImportRequest{Tag: "web"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []SkippedResource{
+		{Resource: dropletResource, Name: "web-1", ID: "7", Reason: "More than one droplet is named web-1"},
+		{Resource: dropletResource, Name: "web-1", ID: "8", Reason: "More than one droplet is named web-1"},
+	}
+	if len(selected.Droplets) != 0 || !reflect.DeepEqual(expected, selected.Skipped) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, selected.Skipped)
+	}
+
+	twinCluster := ExpectedDB
+	twinCluster.ID = "TestID-2131242"
+	infra.databases.client = &MockGodoDatabaseSvc{listed: []godo.Database{ExpectedDB, twinCluster}}
+	selected, err = infra.Import(ImportRequest{ClusterIDs: []string{ExpectedDB.ID, twinCluster.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []SkippedResource{
+		{Resource: databaseResource, Name: "dbtest", ID: ExpectedDB.ID, Reason: "More than one database cluster is named dbtest"},
+		{Resource: databaseResource, Name: "dbtest", ID: twinCluster.ID, Reason: "More than one database cluster is named dbtest"},
+	}
+	if len(selected.Databases) != 0 || !reflect.DeepEqual(expected, selected.Skipped) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, selected.Skipped)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Invalid name pattern web-[. syntax error in pattern"
+		_, returnedError := infra.Import(ImportRequest{NamePattern: "web-["})
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestImportDroplet(t *testing.T) {
+
+	expected := CreateDropletRequest{
+		Name:        "web-1",
+		Region:      NYC3,
+		DropletSize: S1Cpu2GbRAM,
+		Image:       "ubuntu-22-04-x64",
+		Backups:     true,
+		Monitoring:  true,
+		Volumes:     []string{"vol-1"},
+		Tags:        []string{"web"},
+		VPCUUID:     "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+	}
+	returned, err := importDroplet(TestImportedDroplet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		droplet := TestImportedDroplet
+		droplet.Image = &godo.Image{ID: 1234}
+		_, returnedError := importDroplet(droplet)
+		if returnedError == nil || returnedError.Error() != "The droplet's image has no slug" {
+			t.Errorf("unexpected error: %v", returnedError)
+		}
+	})
+
+}
+
+func TestImportCluster(t *testing.T) {
+
+	infra := newTestInfrastructure()
+
+	cluster := ExpectedDB
+	cluster.SizeSlug = "db-s-2vcpu-4gb"
+	expected := DatabaseClusterSpec{
+		CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{
+			Name:         "dbtest",
+			DatabaseType: MySQL,
+			Version:      "11",
+			DatabaseSize: DbS2Cpu4GbRAM38GbStorage,
+			Region:       SFO2,
+			NumNodes:     3,
+			Tags:         []string{"production", "staging"},
+			VPC:          "test private network uuid",
+		},
+		MaintenanceWindow: &MaintenanceWindow{Day: "monday", Time: "13:51"},
+		DBNames:           []string{"Test Database Name"},
+	}
+	returned, err := infra.importCluster(t.Context(), cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
@@ -0,0 +1,356 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateKubernetesClusterRequest struct {
+	Name string
+	Region
+	Version      string
+	Tags         []string
+	VPCUUID      string
+	HA           bool
+	AutoUpgrade  bool
+	SurgeUpgrade bool
+	NodePools    []CreateNodePoolRequest
+}
+
+type FindAllKubernetesClustersRequest struct {
+	Page    int
+	PerPage int
+}
+
+type UpgradeKubernetesClusterRequest struct {
+	ID      string
+	Version string
+}
+
+type DeleteKubernetesClusterRequest struct {
+	ID string
+}
+
+// CreateNodePoolRequest leaves ClusterID empty when nested in a
+// CreateKubernetesClusterRequest.
+type CreateNodePoolRequest struct {
+	ClusterID string
+	Name      string
+	DropletSize
+	Count     int
+	Tags      []string
+	Labels    map[string]string
+	AutoScale bool
+	MinNodes  int
+	MaxNodes  int
+}
+
+type UpdateNodePoolRequest struct {
+	ClusterID string
+	PoolID    string
+	Name      string
+	Tags      []string
+	Labels    map[string]string
+}
+
+type ScaleNodePoolRequest struct {
+	ClusterID string
+	PoolID    string
+	Count     int
+	AutoScale bool
+	MinNodes  int
+	MaxNodes  int
+}
+
+type DeleteNodePoolRequest struct {
+	ClusterID string
+	PoolID    string
+}
+
+// KubeconfigRequest writes the kubeconfig to Path when saved to a file. A zero
+// ExpirySeconds uses the API default.
+type KubeconfigRequest struct {
+	ClusterID     string
+	ExpirySeconds int64
+	Path          string
+}
+
+type WaitForClusterRequest struct {
+	ID      string
+	Timeout time.Duration
+}
+
+type KubernetesClient interface {
+	Create(context.Context, *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error)
+	Get(context.Context, string) (*godo.KubernetesCluster, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error)
+	Upgrade(context.Context, string, *godo.KubernetesClusterUpgradeRequest) (*godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	GetKubeConfig(context.Context, string, *godo.KubernetesClusterKubeconfigGetRequest) (*godo.KubernetesClusterConfig, *godo.Response, error)
+	GetKubeConfigWithExpiry(context.Context, string, int64) (*godo.KubernetesClusterConfig, *godo.Response, error)
+	CreateNodePool(context.Context, string, *godo.KubernetesNodePoolCreateRequest) (*godo.KubernetesNodePool, *godo.Response, error)
+	GetNodePool(context.Context, string, string) (*godo.KubernetesNodePool, *godo.Response, error)
+	ListNodePools(context.Context, string, *godo.ListOptions) ([]*godo.KubernetesNodePool, *godo.Response, error)
+	UpdateNodePool(context.Context, string, string, *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error)
+	DeleteNodePool(context.Context, string, string) (*godo.Response, error)
+}
+
+type Kubernetes struct {
+	client       KubernetesClient
+	pollInterval time.Duration
+}
+
+func NewKC(pat string) Kubernetes {
+	client := Authenticate(pat)
+	return Kubernetes{client: client.Kubernetes, pollInterval: 10 * time.Second}
+}
+
+func (k *Kubernetes) CreateCluster(ckcr CreateKubernetesClusterRequest) (*godo.KubernetesCluster, error) {
+
+	create := &godo.KubernetesClusterCreateRequest{
+		Name:         ckcr.Name,
+		RegionSlug:   ckcr.Region.String(),
+		VersionSlug:  ckcr.Version,
+		Tags:         ckcr.Tags,
+		VPCUUID:      ckcr.VPCUUID,
+		HA:           &ckcr.HA,
+		AutoUpgrade:  ckcr.AutoUpgrade,
+		SurgeUpgrade: ckcr.SurgeUpgrade,
+	}
+	for _, pool := range ckcr.NodePools {
+		create.NodePools = append(create.NodePools, nodePoolCreateRequest(pool))
+	}
+
+	ctx := context.TODO()
+
+	cluster, _, err := k.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create kubernetes cluster. Godo error: " + err.Error())
+	}
+
+	return cluster, nil
+}
+
+func (k *Kubernetes) GetCluster(id string) (*godo.KubernetesCluster, error) {
+
+	ctx := context.TODO()
+
+	cluster, _, err := k.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("Kubernetes cluster with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return cluster, nil
+}
+
+func (k *Kubernetes) GetAllClusters(fakcr FindAllKubernetesClustersRequest) ([]*godo.KubernetesCluster, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fakcr.Page,
+		PerPage: fakcr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	clusters, _, err := k.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all kubernetes clusters. Godo error: " + err.Error())
+	}
+
+	return clusters, nil
+}
+
+func (k *Kubernetes) UpgradeCluster(ukcr UpgradeKubernetesClusterRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := k.client.Upgrade(ctx, ukcr.ID, &godo.KubernetesClusterUpgradeRequest{VersionSlug: ukcr.Version})
+	if err != nil {
+		return errors.New("Unable to upgrade kubernetes cluster with id: " + ukcr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (k *Kubernetes) DeleteCluster(dkcr DeleteKubernetesClusterRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := k.client.Delete(ctx, dkcr.ID)
+	if err != nil {
+		return errors.New("Unable to delete kubernetes cluster with id: " + dkcr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (k *Kubernetes) CreateNodePool(cnpr CreateNodePoolRequest) (*godo.KubernetesNodePool, error) {
+
+	ctx := context.TODO()
+
+	pool, _, err := k.client.CreateNodePool(ctx, cnpr.ClusterID, nodePoolCreateRequest(cnpr))
+	if err != nil {
+		return nil, errors.New("Unable to create node pool in kubernetes cluster with id: " + cnpr.ClusterID + ". Godo error: " + err.Error())
+	}
+
+	return pool, nil
+}
+
+func (k *Kubernetes) GetNodePool(clusterID string, poolID string) (*godo.KubernetesNodePool, error) {
+
+	ctx := context.TODO()
+
+	pool, _, err := k.client.GetNodePool(ctx, clusterID, poolID)
+	if err != nil {
+		return nil, errors.New("Node pool with id: " + poolID + ", was not found. Godo error: " + err.Error())
+	}
+
+	return pool, nil
+}
+
+func (k *Kubernetes) GetAllNodePools(clusterID string) ([]*godo.KubernetesNodePool, error) {
+
+	ctx := context.TODO()
+
+	pools, _, err := k.client.ListNodePools(ctx, clusterID, nil)
+	if err != nil {
+		return nil, errors.New("Unable to get node pools in kubernetes cluster with id: " + clusterID + ". Godo error: " + err.Error())
+	}
+
+	return pools, nil
+}
+
+func (k *Kubernetes) UpdateNodePool(unpr UpdateNodePoolRequest) (*godo.KubernetesNodePool, error) {
+
+	update := &godo.KubernetesNodePoolUpdateRequest{
+		Name:   unpr.Name,
+		Tags:   unpr.Tags,
+		Labels: unpr.Labels,
+	}
+
+	ctx := context.TODO()
+
+	pool, _, err := k.client.UpdateNodePool(ctx, unpr.ClusterID, unpr.PoolID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update node pool with id: " + unpr.PoolID + ". Godo error: " + err.Error())
+	}
+
+	return pool, nil
+}
+
+// ScaleNodePool sets a fixed node count, or the autoscaling bounds when
+// AutoScale is set.
+func (k *Kubernetes) ScaleNodePool(snpr ScaleNodePoolRequest) (*godo.KubernetesNodePool, error) {
+
+	update := &godo.KubernetesNodePoolUpdateRequest{
+		AutoScale: &snpr.AutoScale,
+	}
+	if snpr.AutoScale {
+		update.MinNodes = &snpr.MinNodes
+		update.MaxNodes = &snpr.MaxNodes
+	} else {
+		update.Count = &snpr.Count
+	}
+
+	ctx := context.TODO()
+
+	pool, _, err := k.client.UpdateNodePool(ctx, snpr.ClusterID, snpr.PoolID, update)
+	if err != nil {
+		return nil, errors.New("Unable to scale node pool with id: " + snpr.PoolID + ". Godo error: " + err.Error())
+	}
+
+	return pool, nil
+}
+
+func (k *Kubernetes) DeleteNodePool(dnpr DeleteNodePoolRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := k.client.DeleteNodePool(ctx, dnpr.ClusterID, dnpr.PoolID)
+	if err != nil {
+		return errors.New("Unable to delete node pool with id: " + dnpr.PoolID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (k *Kubernetes) GetKubeconfig(kr KubeconfigRequest) ([]byte, error) {
+
+	ctx := context.TODO()
+
+	var config *godo.KubernetesClusterConfig
+	var err error
+	if kr.ExpirySeconds > 0 {
+		config, _, err = k.client.GetKubeConfigWithExpiry(ctx, kr.ClusterID, kr.ExpirySeconds)
+	} else {
+		config, _, err = k.client.GetKubeConfig(ctx, kr.ClusterID, nil)
+	}
+	if err != nil {
+		return nil, errors.New("Unable to get kubeconfig for kubernetes cluster with id: " + kr.ClusterID + ". Godo error: " + err.Error())
+	}
+
+	return config.KubeconfigYAML, nil
+}
+
+// WriteKubeconfig saves the kubeconfig to Path with 0600 permissions.
+func (k *Kubernetes) WriteKubeconfig(kr KubeconfigRequest) error {
+
+	config, err := k.GetKubeconfig(kr)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(kr.Path, config, 0600)
+	if err != nil {
+		return errors.New("Unable to write kubeconfig to " + kr.Path + ". " + err.Error())
+	}
+	return nil
+}
+
+// WaitUntilRunning polls the cluster until it is running, fails, or Timeout
+// passes.
+func (k *Kubernetes) WaitUntilRunning(wfcr WaitForClusterRequest) (*godo.KubernetesCluster, error) {
+
+	ctx := context.TODO()
+	if wfcr.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wfcr.Timeout)
+		defer cancel()
+	}
+
+	for {
+		cluster, _, err := k.client.Get(ctx, wfcr.ID)
+		if err != nil {
+			return nil, errors.New("Unable to get Kubernetes cluster with id: " + wfcr.ID + ". Godo error: " + err.Error())
+		}
+
+		if cluster.Status != nil {
+			switch cluster.Status.State {
+			case godo.KubernetesClusterStatusRunning:
+				return cluster, nil
+			case godo.KubernetesClusterStatusError, godo.KubernetesClusterStatusDeleted, godo.KubernetesClusterStatusInvalid:
+				return cluster, errors.New("Kubernetes cluster with id: " + wfcr.ID + " is " + string(cluster.Status.State) + ". " + cluster.Status.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return cluster, errors.New("Timed out waiting for kubernetes cluster with id: " + wfcr.ID + " to be running")
+		case <-time.After(k.pollInterval):
+		}
+	}
+}
+
+func nodePoolCreateRequest(cnpr CreateNodePoolRequest) *godo.KubernetesNodePoolCreateRequest {
+	return &godo.KubernetesNodePoolCreateRequest{
+		Name:      cnpr.Name,
+		Size:      cnpr.DropletSize.String(),
+		Count:     cnpr.Count,
+		Tags:      cnpr.Tags,
+		Labels:    cnpr.Labels,
+		AutoScale: cnpr.AutoScale,
+		MinNodes:  cnpr.MinNodes,
+		MaxNodes:  cnpr.MaxNodes,
+	}
+}
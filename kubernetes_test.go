@@ -0,0 +1,194 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestNodePool = godo.KubernetesNodePool{
+	ID:    "cdda885e-7663-40c8-bc74-3a036c66545d",
+	Name:  "pool",
+	Size:  "s-2vcpu-4gb",
+	Count: 3,
+}
+
+var TestNodePools = []*godo.KubernetesNodePool{&TestNodePool}
+
+var TestKubernetesCluster = godo.KubernetesCluster{
+	ID:          "8d91899c-0739-4a1a-acc5-deadbeefbb8f",
+	Name:        "dog-cluster",
+	RegionSlug:  "nyc3",
+	VersionSlug: "1.30.1-do.0",
+	NodePools:   TestNodePools,
+	Status:      &godo.KubernetesClusterStatus{State: godo.KubernetesClusterStatusRunning},
+}
+
+var TestKubernetesClusters = []*godo.KubernetesCluster{&TestKubernetesCluster}
+
+var TestKubeconfig = []byte("apiVersion: v1\nkind: Config\n")
+
+var TestCreateKubernetesClusterRequest = CreateKubernetesClusterRequest{
+	Name:    "dog-cluster",
+	Region:  NYC3,
+	Version: "1.30.1-do.0",
+	NodePools: []CreateNodePoolRequest{
+		{Name: "pool", DropletSize: S2Cpu4GbRAM, Count: 3},
+	},
+}
+
+func newTestKubernetes() Kubernetes {
+	kClient := NewKC(TestPAT)
+	kClient.client = &MockGodoKubernetesSvc{}
+	kClient.pollInterval = time.Millisecond
+	return kClient
+}
+
+func TestCreateCluster(t *testing.T) {
+
+	kClient := newTestKubernetes()
+
+	expected := &TestKubernetesCluster
+	returned, _ := kClient.CreateCluster(TestCreateKubernetesClusterRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetAllClusters(t *testing.T) {
+
+	kClient := newTestKubernetes()
+
+	expected := TestKubernetesClusters
+	returned, _ := kClient.GetAllClusters(FindAllKubernetesClustersRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestScaleNodePool(t *testing.T) {
+
+	kClient := newTestKubernetes()
+
+	expected := &TestNodePool
+	returned, _ := kClient.ScaleNodePool(ScaleNodePoolRequest{ClusterID: TestKubernetesCluster.ID, PoolID: TestNodePool.ID, Count: 3})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteNodePool(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		kClient := newTestKubernetes()
+
+		expectedError := "Unable to delete node pool with id: " + TestNodePool.ID + ". Godo error: " + TestError
+		returnedError := kClient.DeleteNodePool(DeleteNodePoolRequest{ClusterID: TestKubernetesCluster.ID, PoolID: TestNodePool.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestWriteKubeconfig(t *testing.T) {
+
+	kClient := newTestKubernetes()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	err := kClient.WriteKubeconfig(KubeconfigRequest{ClusterID: TestKubernetesCluster.ID, Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	returned, _ := os.ReadFile(path)
+	if !reflect.DeepEqual(TestKubeconfig, returned) {
+		t.Errorf("expected %s\n , returned, %s\n ", TestKubeconfig, returned)
+	}
+
+}
+
+func TestWaitUntilRunning(t *testing.T) {
+
+	kClient := newTestKubernetes()
+
+	expected := &TestKubernetesCluster
+	returned, _ := kClient.WaitUntilRunning(WaitForClusterRequest{ID: TestKubernetesCluster.ID, Timeout: time.Second})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		kClient.client = &MockGodoKubernetesSvc{getError: context.DeadlineExceeded}
+		expectedError := "Unable to get Kubernetes cluster with id: " + TestKubernetesCluster.ID + ". Godo error: context deadline exceeded"
+		_, returnedError := kClient.WaitUntilRunning(WaitForClusterRequest{ID: TestKubernetesCluster.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoKubernetesSvc struct {
+	getError error
+}
+
+func (m *MockGodoKubernetesSvc) Create(context.Context, *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	return &TestKubernetesCluster, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) Get(context.Context, string) (*godo.KubernetesCluster, *godo.Response, error) {
+	if m.getError != nil {
+		return nil, nil, m.getError
+	}
+	return &TestKubernetesCluster, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) List(context.Context, *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+	return TestKubernetesClusters, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) Upgrade(context.Context, string, *godo.KubernetesClusterUpgradeRequest) (*godo.Response, error) {
+	return nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoKubernetesSvc) GetKubeConfig(context.Context, string, *godo.KubernetesClusterKubeconfigGetRequest) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	return &godo.KubernetesClusterConfig{KubeconfigYAML: TestKubeconfig}, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) GetKubeConfigWithExpiry(context.Context, string, int64) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	return &godo.KubernetesClusterConfig{KubeconfigYAML: TestKubeconfig}, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) CreateNodePool(context.Context, string, *godo.KubernetesNodePoolCreateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	return &TestNodePool, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) GetNodePool(context.Context, string, string) (*godo.KubernetesNodePool, *godo.Response, error) {
+	return &TestNodePool, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) ListNodePools(context.Context, string, *godo.ListOptions) ([]*godo.KubernetesNodePool, *godo.Response, error) {
+	return TestNodePools, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) UpdateNodePool(context.Context, string, string, *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	return &TestNodePool, nil, nil
+}
+
+func (m *MockGodoKubernetesSvc) DeleteNodePool(context.Context, string, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
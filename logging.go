@@ -0,0 +1,108 @@
+package dog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// WithLogger logs each droplet and database operation to logger, with how
+// long it took and the status, request ID and remaining rate limit of the
+// API's response. Tokens, user data and passwords are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// logOperation logs op, started at start, at info level, or at error level
+// when it failed. It does nothing without a logger.
+func logOperation(logger *slog.Logger, op string, start time.Time, resp *godo.Response, err error, attrs ...slog.Attr) {
+	if logger == nil {
+		return
+	}
+
+	attrs = append([]slog.Attr{slog.String("op", op)}, attrs...)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if resp != nil && resp.Response != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get("x-request-id")),
+			slog.Int("rate_limit_remaining", resp.Rate.Remaining),
+		)
+	}
+
+	level := slog.LevelInfo
+	message := op
+	if err != nil {
+		level = slog.LevelError
+		message = op + " failed"
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(context.TODO(), level, message, attrs...)
+}
+
+// Keys whose values are replaced when a request is logged.
+var secretKeys = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"user_data":     true,
+}
+
+const redacted = "REDACTED"
+
+// secretValue logs a godo request as its JSON body, with secrets redacted.
+type secretValue struct {
+	request interface{}
+}
+
+func logRequest(request interface{}) slog.Attr {
+	return slog.Any("request", secretValue{request: request})
+}
+
+func (sv secretValue) LogValue() slog.Value {
+	encoded, err := json.Marshal(sv.request)
+	if err != nil {
+		return slog.StringValue(redacted)
+	}
+	var body interface{}
+	if err := json.Unmarshal(encoded, &body); err != nil {
+		return slog.StringValue(redacted)
+	}
+	return slog.AnyValue(redact(body))
+}
+
+// redact replaces secrets in a decoded JSON body, including the password in
+// a connection URI.
+func redact(body interface{}) interface{} {
+	switch v := body.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if secretKeys[key] {
+				if s, ok := value.(string); !ok || s != "" {
+					v[key] = redacted
+				}
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	case string:
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redacted)
+				return u.String()
+			}
+		}
+	}
+	return body
+}
@@ -0,0 +1,401 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+)
+
+// CreateAlertPolicyRequest scopes the policy to DropletIDs, Tags or both;
+// at least one of them is required.
+type CreateAlertPolicyRequest struct {
+	Description string
+	AlertMetric
+	AlertComparison
+	Value float32
+	AlertWindow
+	DropletIDs []int
+	Tags       []string
+	Emails     []string
+	Slack      []godo.SlackDetails
+	Disabled   bool
+}
+
+type FindAllAlertPoliciesRequest struct {
+	Page    int
+	PerPage int
+}
+
+// UpdateAlertPolicyRequest replaces every field of the policy with ID.
+type UpdateAlertPolicyRequest struct {
+	ID          string
+	Description string
+	AlertMetric
+	AlertComparison
+	Value float32
+	AlertWindow
+	DropletIDs []int
+	Tags       []string
+	Emails     []string
+	Slack      []godo.SlackDetails
+	Disabled   bool
+}
+
+type DeleteAlertPolicyRequest struct {
+	ID string
+}
+
+type DropletMetricsRequest struct {
+	DropletID int
+	Start     time.Time
+	End       time.Time
+}
+
+// DropletBandwidthRequest reads public outbound traffic unless Private or
+// Inbound are set.
+type DropletBandwidthRequest struct {
+	DropletID int
+	Start     time.Time
+	End       time.Time
+	Private   bool
+	Inbound   bool
+}
+
+type MetricPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// MetricSeries is one time series, e.g. a single CPU mode, identified by Labels.
+type MetricSeries struct {
+	Labels map[string]string
+	Points []MetricPoint
+}
+
+// MemoryMetrics holds the droplet memory series in bytes.
+type MemoryMetrics struct {
+	Total     []MetricSeries
+	Available []MetricSeries
+	Free      []MetricSeries
+	Cached    []MetricSeries
+}
+
+// Alert metrics
+type AlertMetric int
+
+const (
+	CPUUtilization AlertMetric = iota
+	MemoryUtilization
+	DiskUtilization
+	PublicOutboundBandwidth
+	PublicInboundBandwidth
+	LoadAverage1
+	LoadAverage5
+	LoadAverage15
+)
+
+func (am AlertMetric) String() string {
+	names := [...]string{
+		godo.DropletCPUUtilizationPercent,
+		godo.DropletMemoryUtilizationPercent,
+		godo.DropletDiskUtilizationPercent,
+		godo.DropletPublicOutboundBandwidthRate,
+		godo.DropletPublicInboundBandwidthRate,
+		godo.DropletOneMinuteLoadAverage,
+		godo.DropletFiveMinuteLoadAverage,
+		godo.DropletFifteenMinuteLoadAverage,
+	}
+	if am < CPUUtilization || am > LoadAverage15 {
+		return "That is not an alert metric"
+	}
+	return names[am]
+}
+
+// Alert comparisons
+type AlertComparison int
+
+const (
+	Above AlertComparison = iota
+	Below
+)
+
+func (ac AlertComparison) String() string {
+	names := [...]string{
+		string(godo.GreaterThan),
+		string(godo.LessThan),
+	}
+	if ac < Above || ac > Below {
+		return "That is not an alert comparison"
+	}
+	return names[ac]
+}
+
+// Alert windows
+type AlertWindow int
+
+const (
+	FiveMinutes AlertWindow = iota
+	TenMinutes
+	ThirtyMinutes
+	OneHour
+)
+
+func (aw AlertWindow) String() string {
+	names := [...]string{
+		"5m",
+		"10m",
+		"30m",
+		"1h",
+	}
+	if aw < FiveMinutes || aw > OneHour {
+		return "That is not an alert window"
+	}
+	return names[aw]
+}
+
+type MonitoringClient interface {
+	ListAlertPolicies(context.Context, *godo.ListOptions) ([]godo.AlertPolicy, *godo.Response, error)
+	GetAlertPolicy(context.Context, string) (*godo.AlertPolicy, *godo.Response, error)
+	CreateAlertPolicy(context.Context, *godo.AlertPolicyCreateRequest) (*godo.AlertPolicy, *godo.Response, error)
+	UpdateAlertPolicy(context.Context, string, *godo.AlertPolicyUpdateRequest) (*godo.AlertPolicy, *godo.Response, error)
+	DeleteAlertPolicy(context.Context, string) (*godo.Response, error)
+	GetDropletBandwidth(context.Context, *godo.DropletBandwidthMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	GetDropletCPU(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	GetDropletTotalMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	GetDropletAvailableMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	GetDropletFreeMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	GetDropletCachedMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+}
+
+type Monitoring struct {
+	client MonitoringClient
+}
+
+func NewMC(pat string) Monitoring {
+	client := Authenticate(pat)
+	return Monitoring{client: client.Monitoring}
+}
+
+func (m *Monitoring) GetAllAlertPolicies(faapr FindAllAlertPoliciesRequest) ([]godo.AlertPolicy, error) {
+
+	opt := &godo.ListOptions{
+		Page:    faapr.Page,
+		PerPage: faapr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	policies, _, err := m.client.ListAlertPolicies(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all alert policies. Godo error: " + err.Error())
+	}
+
+	return policies, nil
+}
+
+func (m *Monitoring) GetAlertPolicy(id string) (*godo.AlertPolicy, error) {
+
+	ctx := context.TODO()
+
+	policy, _, err := m.client.GetAlertPolicy(ctx, id)
+	if err != nil {
+		return nil, errors.New("Alert policy with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return policy, nil
+}
+
+func (m *Monitoring) CreateAlertPolicy(capr CreateAlertPolicyRequest) (*godo.AlertPolicy, error) {
+
+	if len(capr.DropletIDs) == 0 && len(capr.Tags) == 0 {
+		return nil, errors.New("Unable to create alert policy. A droplet id or tag is required")
+	}
+
+	enabled := !capr.Disabled
+	create := &godo.AlertPolicyCreateRequest{
+		Type:        capr.AlertMetric.String(),
+		Description: capr.Description,
+		Compare:     godo.AlertPolicyComp(capr.AlertComparison.String()),
+		Value:       capr.Value,
+		Window:      capr.AlertWindow.String(),
+		Entities:    alertEntities(capr.DropletIDs),
+		Tags:        capr.Tags,
+		Alerts:      godo.Alerts{Email: capr.Emails, Slack: capr.Slack},
+		Enabled:     &enabled,
+	}
+
+	ctx := context.TODO()
+
+	policy, _, err := m.client.CreateAlertPolicy(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create alert policy. Godo error: " + err.Error())
+	}
+
+	return policy, nil
+}
+
+func (m *Monitoring) UpdateAlertPolicy(uapr UpdateAlertPolicyRequest) (*godo.AlertPolicy, error) {
+
+	if len(uapr.DropletIDs) == 0 && len(uapr.Tags) == 0 {
+		return nil, errors.New("Unable to update alert policy with id: " + uapr.ID + ". A droplet id or tag is required")
+	}
+
+	enabled := !uapr.Disabled
+	update := &godo.AlertPolicyUpdateRequest{
+		Type:        uapr.AlertMetric.String(),
+		Description: uapr.Description,
+		Compare:     godo.AlertPolicyComp(uapr.AlertComparison.String()),
+		Value:       uapr.Value,
+		Window:      uapr.AlertWindow.String(),
+		Entities:    alertEntities(uapr.DropletIDs),
+		Tags:        uapr.Tags,
+		Alerts:      godo.Alerts{Email: uapr.Emails, Slack: uapr.Slack},
+		Enabled:     &enabled,
+	}
+
+	ctx := context.TODO()
+
+	policy, _, err := m.client.UpdateAlertPolicy(ctx, uapr.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update alert policy with id: " + uapr.ID + ". Godo error: " + err.Error())
+	}
+
+	return policy, nil
+}
+
+func (m *Monitoring) DeleteAlertPolicy(dapr DeleteAlertPolicyRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := m.client.DeleteAlertPolicy(ctx, dapr.ID)
+	if err != nil {
+		return errors.New("Unable to delete alert policy with id: " + dapr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+// GetDropletBandwidth returns the bandwidth in Mbps.
+func (m *Monitoring) GetDropletBandwidth(dbr DropletBandwidthRequest) ([]MetricSeries, error) {
+
+	request := &godo.DropletBandwidthMetricsRequest{
+		DropletMetricsRequest: godo.DropletMetricsRequest{
+			HostID: strconv.Itoa(dbr.DropletID),
+			Start:  dbr.Start,
+			End:    dbr.End,
+		},
+		Interface: "public",
+		Direction: "outbound",
+	}
+	if dbr.Private {
+		request.Interface = "private"
+	}
+	if dbr.Inbound {
+		request.Direction = "inbound"
+	}
+
+	ctx := context.TODO()
+
+	response, _, err := m.client.GetDropletBandwidth(ctx, request)
+	if err != nil {
+		return nil, errors.New("Unable to get bandwidth of droplet with id: " + strconv.Itoa(dbr.DropletID) + ". Godo error: " + err.Error())
+	}
+
+	return metricSeries(response), nil
+}
+
+// GetDropletCPU returns one series per CPU mode, labelled by "mode", of
+// cumulative CPU seconds.
+func (m *Monitoring) GetDropletCPU(dmr DropletMetricsRequest) ([]MetricSeries, error) {
+
+	ctx := context.TODO()
+
+	response, _, err := m.client.GetDropletCPU(ctx, godoMetricsRequest(dmr))
+	if err != nil {
+		return nil, errors.New("Unable to get CPU of droplet with id: " + strconv.Itoa(dmr.DropletID) + ". Godo error: " + err.Error())
+	}
+
+	return metricSeries(response), nil
+}
+
+func (m *Monitoring) GetDropletMemory(dmr DropletMetricsRequest) (*MemoryMetrics, error) {
+
+	ctx := context.TODO()
+
+	type memoryQuery struct {
+		series *[]MetricSeries
+		get    func(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+	}
+
+	memory := &MemoryMetrics{}
+	queries := []memoryQuery{
+		{&memory.Total, m.client.GetDropletTotalMemory},
+		{&memory.Available, m.client.GetDropletAvailableMemory},
+		{&memory.Free, m.client.GetDropletFreeMemory},
+		{&memory.Cached, m.client.GetDropletCachedMemory},
+	}
+
+	request := godoMetricsRequest(dmr)
+	for _, query := range queries {
+		response, _, err := query.get(ctx, request)
+		if err != nil {
+			return nil, errors.New("Unable to get memory of droplet with id: " + strconv.Itoa(dmr.DropletID) + ". Godo error: " + err.Error())
+		}
+		*query.series = metricSeries(response)
+	}
+
+	return memory, nil
+}
+
+func alertEntities(dropletIDs []int) []string {
+	entities := make([]string, len(dropletIDs))
+	for i, id := range dropletIDs {
+		entities[i] = strconv.Itoa(id)
+	}
+	return entities
+}
+
+func godoMetricsRequest(dmr DropletMetricsRequest) *godo.DropletMetricsRequest {
+	return &godo.DropletMetricsRequest{
+		HostID: strconv.Itoa(dmr.DropletID),
+		Start:  dmr.Start,
+		End:    dmr.End,
+	}
+}
+
+func metricSeries(response *godo.MetricsResponse) []MetricSeries {
+	if response == nil {
+		return nil
+	}
+
+	series := make([]MetricSeries, 0, len(response.Data.Result))
+	for _, stream := range response.Data.Result {
+		series = append(series, MetricSeries{
+			Labels: metricLabels(stream.Metric),
+			Points: metricPoints(stream.Values),
+		})
+	}
+	return series
+}
+
+func metricLabels(metric metrics.Metric) map[string]string {
+	labels := make(map[string]string, len(metric))
+	for name, value := range metric {
+		labels[string(name)] = string(value)
+	}
+	return labels
+}
+
+func metricPoints(values []metrics.SamplePair) []MetricPoint {
+	points := make([]MetricPoint, len(values))
+	for i, value := range values {
+		points[i] = MetricPoint{
+			Time:  value.Timestamp.Time().UTC(),
+			Value: float64(value.Value),
+		}
+	}
+	return points
+}
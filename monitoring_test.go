@@ -0,0 +1,205 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+)
+
+var TestAlertPolicy = godo.AlertPolicy{
+	UUID:        "669adfc9-3b3e-4f2b-8f7d-5b4a5e6a7c1d",
+	Type:        godo.DropletCPUUtilizationPercent,
+	Description: "CPU is running high",
+	Compare:     godo.GreaterThan,
+	Value:       80,
+	Window:      "5m",
+	Entities:    []string{"12345"},
+	Alerts:      godo.Alerts{Email: []string{"ops@example.com"}},
+	Enabled:     true,
+}
+
+var TestAlertPolicies = []godo.AlertPolicy{TestAlertPolicy}
+
+var TestMetricsResponse = godo.MetricsResponse{
+	Status: "success",
+	Data: godo.MetricsData{
+		ResultType: "matrix",
+		Result: []metrics.SampleStream{
+			{
+				Metric: metrics.Metric{"host_id": "12345", "mode": "idle"},
+				Values: []metrics.SamplePair{
+					{Timestamp: metrics.TimeFromUnix(1591000000), Value: 42.5},
+				},
+			},
+		},
+	},
+}
+
+var TestMetricSeries = []MetricSeries{
+	{
+		Labels: map[string]string{"host_id": "12345", "mode": "idle"},
+		Points: []MetricPoint{{Time: time.Unix(1591000000, 0).UTC(), Value: 42.5}},
+	},
+}
+
+func TestCreateAlertPolicy(t *testing.T) {
+
+	mock := &MockGodoMonitoringSvc{}
+	mClient := NewMC(TestPAT)
+	mClient.client = mock
+
+	expected := &TestAlertPolicy
+	returned, _ := mClient.CreateAlertPolicy(CreateAlertPolicyRequest{
+		Description:     "CPU is running high",
+		AlertMetric:     CPUUtilization,
+		AlertComparison: Above,
+		Value:           80,
+		AlertWindow:     FiveMinutes,
+		DropletIDs:      []int{12345},
+		Emails:          []string{"ops@example.com"},
+	})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	expectedCreate := &godo.AlertPolicyCreateRequest{
+		Type:        TestAlertPolicy.Type,
+		Description: TestAlertPolicy.Description,
+		Compare:     TestAlertPolicy.Compare,
+		Value:       TestAlertPolicy.Value,
+		Window:      TestAlertPolicy.Window,
+		Entities:    TestAlertPolicy.Entities,
+		Alerts:      TestAlertPolicy.Alerts,
+		Enabled:     &TestAlertPolicy.Enabled,
+	}
+	if !reflect.DeepEqual(expectedCreate, mock.created) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expectedCreate, mock.created)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Unable to create alert policy. A droplet id or tag is required"
+		_, returnedError := mClient.CreateAlertPolicy(CreateAlertPolicyRequest{AlertMetric: MemoryUtilization})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestDeleteAlertPolicy(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		mClient := NewMC(TestPAT)
+		mClient.client = &MockGodoMonitoringSvc{}
+
+		expectedError := "Unable to delete alert policy with id: " + TestAlertPolicy.UUID + ". Godo error: " + TestError
+		returnedError := mClient.DeleteAlertPolicy(DeleteAlertPolicyRequest{ID: TestAlertPolicy.UUID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestGetDropletCPU(t *testing.T) {
+
+	mClient := NewMC(TestPAT)
+	mClient.client = &MockGodoMonitoringSvc{}
+
+	expected := TestMetricSeries
+	returned, _ := mClient.GetDropletCPU(DropletMetricsRequest{DropletID: 12345, Start: time.Unix(1590990000, 0), End: time.Unix(1591000000, 0)})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetDropletBandwidth(t *testing.T) {
+
+	mock := &MockGodoMonitoringSvc{}
+	mClient := NewMC(TestPAT)
+	mClient.client = mock
+
+	_, err := mClient.GetDropletBandwidth(DropletBandwidthRequest{DropletID: 12345, Private: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if mock.bandwidth.Interface != "private" || mock.bandwidth.Direction != "outbound" || mock.bandwidth.HostID != "12345" {
+		t.Errorf("unexpected bandwidth request: %+v", mock.bandwidth)
+	}
+
+}
+
+func TestGetDropletMemory(t *testing.T) {
+
+	mClient := NewMC(TestPAT)
+	mClient.client = &MockGodoMonitoringSvc{}
+
+	expected := &MemoryMetrics{
+		Total:     TestMetricSeries,
+		Available: TestMetricSeries,
+		Free:      TestMetricSeries,
+		Cached:    TestMetricSeries,
+	}
+	returned, _ := mClient.GetDropletMemory(DropletMetricsRequest{DropletID: 12345})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+type MockGodoMonitoringSvc struct {
+	created   *godo.AlertPolicyCreateRequest
+	bandwidth *godo.DropletBandwidthMetricsRequest
+}
+
+func (m *MockGodoMonitoringSvc) ListAlertPolicies(context.Context, *godo.ListOptions) ([]godo.AlertPolicy, *godo.Response, error) {
+	return TestAlertPolicies, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetAlertPolicy(context.Context, string) (*godo.AlertPolicy, *godo.Response, error) {
+	return &TestAlertPolicy, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) CreateAlertPolicy(_ context.Context, request *godo.AlertPolicyCreateRequest) (*godo.AlertPolicy, *godo.Response, error) {
+	m.created = request
+	return &TestAlertPolicy, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) UpdateAlertPolicy(context.Context, string, *godo.AlertPolicyUpdateRequest) (*godo.AlertPolicy, *godo.Response, error) {
+	return &TestAlertPolicy, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) DeleteAlertPolicy(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletBandwidth(_ context.Context, request *godo.DropletBandwidthMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.bandwidth = request
+	return &TestMetricsResponse, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletCPU(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	return &TestMetricsResponse, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletTotalMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	return &TestMetricsResponse, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletAvailableMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	return &TestMetricsResponse, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletFreeMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	return &TestMetricsResponse, nil, nil
+}
+
+func (m *MockGodoMonitoringSvc) GetDropletCachedMemory(context.Context, *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	return &TestMetricsResponse, nil, nil
+}
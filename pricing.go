@@ -0,0 +1,233 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// Droplets and clusters are billed hourly up to 672 hours a month.
+const hoursPerMonth = 672
+
+// Backups cost 20% of the droplet price and volumes are billed per GB.
+const (
+	backupsSurcharge      = 0.2
+	volumePricePerGBMonth = 0.10
+)
+
+// List prices in USD at the time of writing; LoadCatalog replaces the droplet
+// prices with the ones on the account.
+var dropletPrices = map[DropletSize]float64{
+	S1Cpu1GbRAM:    6,
+	S1Cpu2GbRAM:    12,
+	S1Cpu3GbRAM:    18,
+	S2Cpu2GbRAM:    18,
+	S3Cpu1GbRAM:    15,
+	S2Cpu4GbRAM:    24,
+	S4Cpu8GbRAM:    48,
+	S6Cpu16GbRAM:   96,
+	S8Cpu32GbRAM:   192,
+	S12Cpu47GbRAM:  288,
+	S16Cpu64GbRAM:  384,
+	S20Cpu96GbRAM:  576,
+	S24Cpu128GbRAM: 768,
+	S32Cpu19GbRAM:  1152,
+}
+
+var databasePrices = map[DatabaseSize]float64{
+	DbS1Cpu1GbRAM10GbStorage:     15,
+	DbS1Cpu2GbRAM25GbStorage:     30,
+	DbS2Cpu4GbRAM38GbStorage:     60,
+	DbS4Cpu8GbRAM115GbStorage:    120,
+	DbS6Cpu16GbRAM270GbStorage:   240,
+	DbS8Cpu32GbRAM580GbStorage:   480,
+	DbS16Cpu64GbRAM1120GbStorage: 960,
+}
+
+type Price struct {
+	Monthly float64
+	Hourly  float64
+}
+
+type EstimateLine struct {
+	Description string
+	Price
+}
+
+// Estimate is the total price along with the lines that make it up.
+type Estimate struct {
+	Price
+	Lines []EstimateLine
+}
+
+type SizeClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.Size, *godo.Response, error)
+}
+
+type VolumeClient interface {
+	GetVolume(context.Context, string) (*godo.Volume, *godo.Response, error)
+}
+
+type Pricing struct {
+	sizes   SizeClient
+	volumes VolumeClient
+	prices  map[string]Price
+}
+
+func NewPrC(pat string) Pricing {
+	client := Authenticate(pat)
+	return Pricing{sizes: client.Sizes, volumes: client.Storage, prices: defaultPrices()}
+}
+
+// LoadCatalog adds every droplet size on the account, including sizes with
+// no DropletSize, at its current price.
+func (p *Pricing) LoadCatalog() error {
+
+	ctx := context.TODO()
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		sizes, resp, err := p.sizes.List(ctx, opt)
+		if err != nil {
+			return errors.New("Unable to load size catalog. Godo error: " + err.Error())
+		}
+
+		for _, size := range sizes {
+			p.prices[size.Slug] = Price{Monthly: size.PriceMonthly, Hourly: size.PriceHourly}
+		}
+
+		if isLastPage(resp) {
+			break
+		}
+		opt.Page++
+	}
+	return nil
+}
+
+// SizePrice is the price of the droplet or database size slug.
+func (p *Pricing) SizePrice(slug string) (Price, error) {
+	price, ok := p.prices[slug]
+	if !ok {
+		return Price{}, errors.New("No price is known for size: " + slug)
+	}
+	return price, nil
+}
+
+func (p *Pricing) DropletPrice(size DropletSize) (Price, error) {
+	return p.SizePrice(size.String())
+}
+
+func (p *Pricing) DatabasePrice(size DatabaseSize) (Price, error) {
+	return p.SizePrice(size.String())
+}
+
+// EstimateDroplet prices the droplet cdr would create, its backups and the
+// volumes attached to it.
+func (p *Pricing) EstimateDroplet(cdr CreateDropletRequest) (*Estimate, error) {
+
+	price, err := p.DropletPrice(cdr.DropletSize)
+	if err != nil {
+		return nil, errors.New("Unable to estimate droplet. " + err.Error())
+	}
+
+	estimate := &Estimate{}
+	estimate.add("Droplet "+cdr.DropletSize.String(), price)
+	if cdr.Backups {
+		estimate.add("Backups", scalePrice(price, backupsSurcharge))
+	}
+
+	ctx := context.TODO()
+
+	for _, id := range cdr.Volumes {
+		volume, _, err := p.volumes.GetVolume(ctx, id)
+		if err != nil {
+			return nil, errors.New("Unable to estimate droplet. Volume with id: " + id + ", was not found. Godo error: " + err.Error())
+		}
+		estimate.add("Volume "+volume.Name, monthlyPrice(float64(volume.SizeGigaBytes)*volumePricePerGBMonth))
+	}
+
+	return estimate, nil
+}
+
+// EstimateDatabaseCluster prices every node of the cluster cdcr would create.
+func (p *Pricing) EstimateDatabaseCluster(cdcr CreateDatabaseClusterRequest) (*Estimate, error) {
+
+	price, err := p.DatabasePrice(cdcr.DatabaseSize)
+	if err != nil {
+		return nil, errors.New("Unable to estimate database cluster. " + err.Error())
+	}
+
+	nodes := cdcr.NumNodes
+	if nodes < 1 {
+		nodes = 1
+	}
+
+	estimate := &Estimate{}
+	for i := 0; i < nodes; i++ {
+		estimate.add("Database node "+cdcr.DatabaseSize.String(), price)
+	}
+
+	return estimate, nil
+}
+
+// RunRate is the monthly price of droplets, e.g. those from GetAllDroplets,
+// including the backups enabled on them.
+func (p *Pricing) RunRate(droplets []godo.Droplet) (*Estimate, error) {
+
+	estimate := &Estimate{}
+	for _, droplet := range droplets {
+		var price Price
+		if droplet.Size != nil && droplet.Size.PriceMonthly > 0 {
+			price = Price{Monthly: droplet.Size.PriceMonthly, Hourly: droplet.Size.PriceHourly}
+		} else {
+			found, err := p.SizePrice(droplet.SizeSlug)
+			if err != nil {
+				return nil, errors.New("Unable to price droplet with name: " + droplet.Name + ". " + err.Error())
+			}
+			price = found
+		}
+
+		estimate.add("Droplet "+droplet.Name, price)
+		if hasFeature(droplet, "backups") {
+			estimate.add("Backups of "+droplet.Name, scalePrice(price, backupsSurcharge))
+		}
+	}
+
+	return estimate, nil
+}
+
+func (e *Estimate) add(description string, price Price) {
+	e.Lines = append(e.Lines, EstimateLine{Description: description, Price: price})
+	e.Monthly += price.Monthly
+	e.Hourly += price.Hourly
+}
+
+func defaultPrices() map[string]Price {
+	prices := make(map[string]Price, len(dropletPrices)+len(databasePrices))
+	for size, monthly := range dropletPrices {
+		prices[size.String()] = monthlyPrice(monthly)
+	}
+	for size, monthly := range databasePrices {
+		prices[size.String()] = monthlyPrice(monthly)
+	}
+	return prices
+}
+
+func monthlyPrice(monthly float64) Price {
+	return Price{Monthly: monthly, Hourly: monthly / hoursPerMonth}
+}
+
+func scalePrice(price Price, factor float64) Price {
+	return Price{Monthly: price.Monthly * factor, Hourly: price.Hourly * factor}
+}
+
+func hasFeature(droplet godo.Droplet, feature string) bool {
+	for _, f := range droplet.Features {
+		if strings.EqualFold(f, feature) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestVolume = godo.Volume{
+	ID:            "506f78a4-e098-11e5-ad9f-000f53306ae1",
+	Name:          "test-volume",
+	SizeGigaBytes: 100,
+}
+
+var TestSizes = []godo.Size{
+	{Slug: "s-1vcpu-1gb", PriceMonthly: 4, PriceHourly: 0.00595},
+	{Slug: "c-2", PriceMonthly: 42, PriceHourly: 0.0625},
+}
+
+func newTestPricing() Pricing {
+	pClient := NewPrC(TestPAT)
+	pClient.sizes = &MockGodoSizeSvc{}
+	pClient.volumes = &MockGodoVolumeSvc{}
+	return pClient
+}
+
+func TestDropletPrice(t *testing.T) {
+
+	pClient := newTestPricing()
+
+	expected := Price{Monthly: 6, Hourly: 6.0 / 672}
+	returned, _ := pClient.DropletPrice(S1Cpu1GbRAM)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "No price is known for size: That is not a droplet size"
+		_, returnedError := pClient.DropletPrice(DropletSize(-1))
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestLoadCatalog(t *testing.T) {
+
+	pClient := newTestPricing()
+
+	err := pClient.LoadCatalog()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := Price{Monthly: 42, Hourly: 0.0625}
+	returned, _ := pClient.SizePrice("c-2")
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	expected = Price{Monthly: 4, Hourly: 0.00595}
+	returned, _ = pClient.DropletPrice(S1Cpu1GbRAM)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestEstimateDroplet(t *testing.T) {
+
+	pClient := newTestPricing()
+
+	returned, err := pClient.EstimateDroplet(CreateDropletRequest{DropletSize: S2Cpu4GbRAM, Backups: true, Volumes: []string{TestVolume.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// 24 for the droplet, 4.80 for backups and 10 for the 100GB volume
+	if len(returned.Lines) != 3 || math.Abs(returned.Monthly-38.8) > 1e-9 {
+		t.Errorf("unexpected estimate: %+v", returned)
+	}
+
+}
+
+func TestEstimateDatabaseCluster(t *testing.T) {
+
+	pClient := newTestPricing()
+
+	returned, _ := pClient.EstimateDatabaseCluster(CreateDatabaseClusterRequest{DatabaseSize: DbS2Cpu4GbRAM38GbStorage, NumNodes: 3})
+	if len(returned.Lines) != 3 || math.Abs(returned.Monthly-180) > 1e-9 {
+		t.Errorf("unexpected estimate: %+v", returned)
+	}
+
+}
+
+func TestRunRate(t *testing.T) {
+
+	pClient := newTestPricing()
+
+	droplets := []godo.Droplet{
+		{Name: "web", SizeSlug: "s-1vcpu-2gb", Features: []string{"backups"}},
+		{Name: "worker", Size: &godo.Size{PriceMonthly: 48, PriceHourly: 0.07143}},
+	}
+
+	returned, _ := pClient.RunRate(droplets)
+	if len(returned.Lines) != 3 || math.Abs(returned.Monthly-62.4) > 1e-9 {
+		t.Errorf("unexpected run rate: %+v", returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Unable to price droplet with name: gpu. No price is known for size: gpu-h100x1-80gb"
+		_, returnedError := pClient.RunRate([]godo.Droplet{{Name: "gpu", SizeSlug: "gpu-h100x1-80gb"}})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoSizeSvc struct{}
+
+func (m *MockGodoSizeSvc) List(context.Context, *godo.ListOptions) ([]godo.Size, *godo.Response, error) {
+	return TestSizes, nil, nil
+}
+
+type MockGodoVolumeSvc struct{}
+
+func (m *MockGodoVolumeSvc) GetVolume(_ context.Context, id string) (*godo.Volume, *godo.Response, error) {
+	if id != TestVolume.ID {
+		return nil, nil, errors.New(TestError)
+	}
+	return &TestVolume, nil, nil
+}
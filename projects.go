@@ -0,0 +1,257 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateProjectRequest struct {
+	Name        string
+	Description string
+	Purpose     string
+	ProjectEnvironment
+}
+
+type FindAllProjectsRequest struct {
+	Page    int
+	PerPage int
+}
+
+// UpdateProjectRequest leaves empty fields unchanged.
+type UpdateProjectRequest struct {
+	ID          string
+	Name        string
+	Description string
+	Purpose     string
+	ProjectEnvironment
+	IsDefault *bool
+}
+
+type DeleteProjectRequest struct {
+	ID string
+}
+
+type FindProjectResourcesRequest struct {
+	ID      string
+	Page    int
+	PerPage int
+}
+
+type AssignResourcesRequest struct {
+	ID   string
+	URNs []string
+}
+
+// Project environments
+type ProjectEnvironment int
+
+const (
+	NoEnvironment ProjectEnvironment = iota
+	Development
+	Staging
+	Production
+)
+
+func (pe ProjectEnvironment) String() string {
+	names := [...]string{
+		"",
+		"Development",
+		"Staging",
+		"Production",
+	}
+	if pe < NoEnvironment || pe > Production {
+		return "That is not a project environment"
+	}
+	return names[pe]
+}
+
+type ProjectClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.Project, *godo.Response, error)
+	GetDefault(context.Context) (*godo.Project, *godo.Response, error)
+	Get(context.Context, string) (*godo.Project, *godo.Response, error)
+	Create(context.Context, *godo.CreateProjectRequest) (*godo.Project, *godo.Response, error)
+	Update(context.Context, string, *godo.UpdateProjectRequest) (*godo.Project, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	ListResources(context.Context, string, *godo.ListOptions) ([]godo.ProjectResource, *godo.Response, error)
+	AssignResources(context.Context, string, ...interface{}) ([]godo.ProjectResource, *godo.Response, error)
+}
+
+type Project struct {
+	client ProjectClient
+}
+
+func NewPC(pat string) Project {
+	client := Authenticate(pat)
+	return Project{client: client.Projects}
+}
+
+func (p *Project) GetAllProjects(fapr FindAllProjectsRequest) ([]godo.Project, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fapr.Page,
+		PerPage: fapr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	projects, _, err := p.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all projects. Godo error: " + err.Error())
+	}
+
+	return projects, nil
+}
+
+func (p *Project) GetProject(id string) (*godo.Project, error) {
+
+	ctx := context.TODO()
+
+	project, _, err := p.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("Project with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return project, nil
+}
+
+func (p *Project) GetDefaultProject() (*godo.Project, error) {
+
+	ctx := context.TODO()
+
+	project, _, err := p.client.GetDefault(ctx)
+	if err != nil {
+		return nil, errors.New("Unable to get default project. Godo error: " + err.Error())
+	}
+
+	return project, nil
+}
+
+func (p *Project) CreateProject(cpr CreateProjectRequest) (*godo.Project, error) {
+
+	create := &godo.CreateProjectRequest{
+		Name:        cpr.Name,
+		Description: cpr.Description,
+		Purpose:     cpr.Purpose,
+		Environment: cpr.ProjectEnvironment.String(),
+	}
+
+	ctx := context.TODO()
+
+	project, _, err := p.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create project. Godo error: " + err.Error())
+	}
+
+	return project, nil
+}
+
+func (p *Project) UpdateProject(upr UpdateProjectRequest) (*godo.Project, error) {
+
+	update := &godo.UpdateProjectRequest{}
+	if upr.Name != "" {
+		update.Name = upr.Name
+	}
+	if upr.Description != "" {
+		update.Description = upr.Description
+	}
+	if upr.Purpose != "" {
+		update.Purpose = upr.Purpose
+	}
+	if upr.ProjectEnvironment != NoEnvironment {
+		update.Environment = upr.ProjectEnvironment.String()
+	}
+	if upr.IsDefault != nil {
+		update.IsDefault = *upr.IsDefault
+	}
+
+	ctx := context.TODO()
+
+	project, _, err := p.client.Update(ctx, upr.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update project with id: " + upr.ID + ". Godo error: " + err.Error())
+	}
+
+	return project, nil
+}
+
+func (p *Project) DeleteProject(dpr DeleteProjectRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := p.client.Delete(ctx, dpr.ID)
+	if err != nil {
+		return errors.New("Unable to delete project with id: " + dpr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (p *Project) GetProjectResources(fprr FindProjectResourcesRequest) ([]godo.ProjectResource, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fprr.Page,
+		PerPage: fprr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	resources, _, err := p.client.ListResources(ctx, fprr.ID, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get resources of project with id: " + fprr.ID + ". Godo error: " + err.Error())
+	}
+
+	return resources, nil
+}
+
+func (p *Project) AssignResources(arr AssignResourcesRequest) ([]godo.ProjectResource, error) {
+
+	ctx := context.TODO()
+
+	return assignToProject(ctx, observer{}, p.client, arr.ID, arr.URNs...)
+}
+
+func assignToProject(ctx context.Context, o observer, client ProjectClient, id string, urns ...string) ([]godo.ProjectResource, error) {
+
+	resources := make([]interface{}, len(urns))
+	for i, urn := range urns {
+		resources[i] = urn
+	}
+
+	ctx, op := o.begin(ctx, "Projects.AssignResources", slog.String("project_id", id))
+	assigned, resp, err := client.AssignResources(ctx, id, resources...)
+	op.end(resp, err)
+	if err != nil {
+		return nil, errors.New("Unable to assign resources to project with id: " + id + ". Godo error: " + err.Error())
+	}
+
+	return assigned, nil
+}
+
+// findProject looks a project up by either its name or its ID.
+func findProject(ctx context.Context, o observer, client ProjectClient, nameOrID string) (*godo.Project, error) {
+
+	if client == nil {
+		return nil, errors.New("Project: " + nameOrID + " can not be looked up without a project client")
+	}
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		pageCtx, op := o.begin(ctx, "Projects.List", slog.Int("page", opt.Page))
+		projects, resp, err := client.List(pageCtx, opt)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to get all projects. Godo error: " + err.Error())
+		}
+		for i := range projects {
+			if projects[i].ID == nameOrID || projects[i].Name == nameOrID {
+				return &projects[i], nil
+			}
+		}
+		if isLastPage(resp) {
+			return nil, errors.New("Project: " + nameOrID + ", was not found")
+		}
+		opt.Page++
+	}
+}
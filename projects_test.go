@@ -0,0 +1,120 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestProject = godo.Project{
+	ID:          "4e1bfbc3-dc3e-41f2-a18f-1b4d7ba71679",
+	Name:        "dog-project",
+	Purpose:     "Web Application",
+	Environment: "Production",
+}
+
+var TestProjects = []godo.Project{TestProject}
+
+var TestProjectResource = godo.ProjectResource{
+	URN:    "do:droplet:1",
+	Status: "ok",
+}
+
+var TestProjectResources = []godo.ProjectResource{TestProjectResource}
+
+var TestCreateProjectRequest = CreateProjectRequest{
+	Name:               "dog-project",
+	Purpose:            "Web Application",
+	ProjectEnvironment: Production,
+}
+
+func TestGetAllProjects(t *testing.T) {
+
+	pClient := NewPC(TestPAT)
+	pClient.client = &MockGodoProjectSvc{}
+
+	expected := TestProjects
+	returned, _ := pClient.GetAllProjects(FindAllProjectsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateProject(t *testing.T) {
+
+	pClient := NewPC(TestPAT)
+	pClient.client = &MockGodoProjectSvc{}
+
+	expected := &TestProject
+	returned, _ := pClient.CreateProject(TestCreateProjectRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestAssignResources(t *testing.T) {
+
+	pClient := NewPC(TestPAT)
+	pClient.client = &MockGodoProjectSvc{}
+
+	expected := TestProjectResources
+	returned, _ := pClient.AssignResources(AssignResourcesRequest{ID: TestProject.ID, URNs: []string{"do:droplet:1"}})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteProject(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		pClient := NewPC(TestPAT)
+		pClient.client = &MockGodoProjectSvc{}
+
+		expectedError := "Unable to delete project with id: " + TestProject.ID + ". Godo error: " + TestError
+		returnedError := pClient.DeleteProject(DeleteProjectRequest{ID: TestProject.ID})
+		if expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoProjectSvc struct{}
+
+func (m *MockGodoProjectSvc) List(context.Context, *godo.ListOptions) ([]godo.Project, *godo.Response, error) {
+	return TestProjects, nil, nil
+}
+
+func (m *MockGodoProjectSvc) GetDefault(context.Context) (*godo.Project, *godo.Response, error) {
+	return &TestProject, nil, nil
+}
+
+func (m *MockGodoProjectSvc) Get(context.Context, string) (*godo.Project, *godo.Response, error) {
+	return &TestProject, nil, nil
+}
+
+func (m *MockGodoProjectSvc) Create(context.Context, *godo.CreateProjectRequest) (*godo.Project, *godo.Response, error) {
+	return &TestProject, nil, nil
+}
+
+func (m *MockGodoProjectSvc) Update(context.Context, string, *godo.UpdateProjectRequest) (*godo.Project, *godo.Response, error) {
+	return &TestProject, nil, nil
+}
+
+func (m *MockGodoProjectSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoProjectSvc) ListResources(context.Context, string, *godo.ListOptions) ([]godo.ProjectResource, *godo.Response, error) {
+	return TestProjectResources, nil, nil
+}
+
+func (m *MockGodoProjectSvc) AssignResources(context.Context, string, ...interface{}) ([]godo.ProjectResource, *godo.Response, error) {
+	return TestProjectResources, nil, nil
+}
@@ -0,0 +1,283 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateRegistryRequest struct {
+	Name             string
+	SubscriptionTier string
+	Region
+}
+
+type FindRepositoriesRequest struct {
+	Registry string
+	Page     int
+	PerPage  int
+}
+
+type FindRepositoryTagsRequest struct {
+	Registry   string
+	Repository string
+	Page       int
+	PerPage    int
+}
+
+type FindRepositoryManifestsRequest struct {
+	Registry   string
+	Repository string
+	Page       int
+	PerPage    int
+}
+
+type DeleteRepositoryTagRequest struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+type DeleteManifestRequest struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+type GarbageCollectionRequest struct {
+	Registry string
+	GarbageCollectionKind
+}
+
+// DockerCredentialsRequest writes the config.json to Path when saved to a
+// file. A zero Expiry creates credentials that do not expire.
+type DockerCredentialsRequest struct {
+	ReadWrite bool
+	Expiry    time.Duration
+	Path      string
+}
+
+// Garbage collection kinds
+type GarbageCollectionKind int
+
+const (
+	UntaggedManifestsAndUnreferencedBlobs GarbageCollectionKind = iota
+	UntaggedManifestsOnly
+	UnreferencedBlobsOnly
+)
+
+func (gck GarbageCollectionKind) String() string {
+	names := [...]string{
+		"untagged manifests and unreferenced blobs",
+		"untagged manifests only",
+		"unreferenced blobs only",
+	}
+	if gck < UntaggedManifestsAndUnreferencedBlobs || gck > UnreferencedBlobsOnly {
+		return "That is not a garbage collection kind"
+	}
+	return names[gck]
+}
+
+type RegistryClient interface {
+	Create(context.Context, *godo.RegistryCreateRequest) (*godo.Registry, *godo.Response, error)
+	Get(context.Context) (*godo.Registry, *godo.Response, error)
+	Delete(context.Context) (*godo.Response, error)
+	DockerCredentials(context.Context, *godo.RegistryDockerCredentialsRequest) (*godo.DockerCredentials, *godo.Response, error)
+	ListRepositoriesV2(context.Context, string, *godo.TokenListOptions) ([]*godo.RepositoryV2, *godo.Response, error)
+	ListRepositoryTags(context.Context, string, string, *godo.ListOptions) ([]*godo.RepositoryTag, *godo.Response, error)
+	DeleteTag(context.Context, string, string, string) (*godo.Response, error)
+	ListRepositoryManifests(context.Context, string, string, *godo.ListOptions) ([]*godo.RepositoryManifest, *godo.Response, error)
+	DeleteManifest(context.Context, string, string, string) (*godo.Response, error)
+	StartGarbageCollection(context.Context, string, ...*godo.StartGarbageCollectionRequest) (*godo.GarbageCollection, *godo.Response, error)
+	GetGarbageCollection(context.Context, string) (*godo.GarbageCollection, *godo.Response, error)
+}
+
+type Registry struct {
+	client RegistryClient
+}
+
+func NewRC(pat string) Registry {
+	client := Authenticate(pat)
+	return Registry{client: client.Registry}
+}
+
+func (r *Registry) CreateRegistry(crr CreateRegistryRequest) (*godo.Registry, error) {
+
+	create := &godo.RegistryCreateRequest{
+		Name:                 crr.Name,
+		SubscriptionTierSlug: crr.SubscriptionTier,
+		Region:               crr.Region.String(),
+	}
+
+	ctx := context.TODO()
+
+	registry, _, err := r.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create registry. Godo error: " + err.Error())
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) GetRegistry() (*godo.Registry, error) {
+
+	ctx := context.TODO()
+
+	registry, _, err := r.client.Get(ctx)
+	if err != nil {
+		return nil, errors.New("Unable to get registry. Godo error: " + err.Error())
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) DeleteRegistry() error {
+
+	ctx := context.TODO()
+
+	_, err := r.client.Delete(ctx)
+	if err != nil {
+		return errors.New("Unable to delete registry. Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (r *Registry) GetRepositories(frr FindRepositoriesRequest) ([]*godo.RepositoryV2, error) {
+
+	opt := &godo.TokenListOptions{
+		Page:    frr.Page,
+		PerPage: frr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	repositories, _, err := r.client.ListRepositoriesV2(ctx, frr.Registry, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get repositories in registry: " + frr.Registry + ". Godo error: " + err.Error())
+	}
+
+	return repositories, nil
+}
+
+func (r *Registry) GetRepositoryTags(frtr FindRepositoryTagsRequest) ([]*godo.RepositoryTag, error) {
+
+	opt := &godo.ListOptions{
+		Page:    frtr.Page,
+		PerPage: frtr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	tags, _, err := r.client.ListRepositoryTags(ctx, frtr.Registry, frtr.Repository, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get tags in repository: " + frtr.Repository + ". Godo error: " + err.Error())
+	}
+
+	return tags, nil
+}
+
+func (r *Registry) GetRepositoryManifests(frmr FindRepositoryManifestsRequest) ([]*godo.RepositoryManifest, error) {
+
+	opt := &godo.ListOptions{
+		Page:    frmr.Page,
+		PerPage: frmr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	manifests, _, err := r.client.ListRepositoryManifests(ctx, frmr.Registry, frmr.Repository, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get manifests in repository: " + frmr.Repository + ". Godo error: " + err.Error())
+	}
+
+	return manifests, nil
+}
+
+func (r *Registry) DeleteRepositoryTag(drtr DeleteRepositoryTagRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := r.client.DeleteTag(ctx, drtr.Registry, drtr.Repository, drtr.Tag)
+	if err != nil {
+		return errors.New("Unable to delete tag: " + drtr.Tag + " in repository: " + drtr.Repository + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (r *Registry) DeleteManifest(dmr DeleteManifestRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := r.client.DeleteManifest(ctx, dmr.Registry, dmr.Repository, dmr.Digest)
+	if err != nil {
+		return errors.New("Unable to delete manifest: " + dmr.Digest + " in repository: " + dmr.Repository + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (r *Registry) StartGarbageCollection(gcr GarbageCollectionRequest) (*godo.GarbageCollection, error) {
+
+	start := &godo.StartGarbageCollectionRequest{
+		Type: godo.GarbageCollectionType(gcr.GarbageCollectionKind.String()),
+	}
+
+	ctx := context.TODO()
+
+	gc, _, err := r.client.StartGarbageCollection(ctx, gcr.Registry, start)
+	if err != nil {
+		return nil, errors.New("Unable to start garbage collection in registry: " + gcr.Registry + ". Godo error: " + err.Error())
+	}
+
+	return gc, nil
+}
+
+func (r *Registry) GetGarbageCollection(registry string) (*godo.GarbageCollection, error) {
+
+	ctx := context.TODO()
+
+	gc, _, err := r.client.GetGarbageCollection(ctx, registry)
+	if err != nil {
+		return nil, errors.New("Unable to get garbage collection in registry: " + registry + ". Godo error: " + err.Error())
+	}
+
+	return gc, nil
+}
+
+// DockerCredentials returns a Docker config.json for the registry.
+func (r *Registry) DockerCredentials(dcr DockerCredentialsRequest) ([]byte, error) {
+
+	request := &godo.RegistryDockerCredentialsRequest{
+		ReadWrite: dcr.ReadWrite,
+	}
+	if dcr.Expiry > 0 {
+		seconds := int(dcr.Expiry.Seconds())
+		request.ExpirySeconds = &seconds
+	}
+
+	ctx := context.TODO()
+
+	credentials, _, err := r.client.DockerCredentials(ctx, request)
+	if err != nil {
+		return nil, errors.New("Unable to get docker credentials. Godo error: " + err.Error())
+	}
+
+	return credentials.DockerConfigJSON, nil
+}
+
+// WriteDockerCredentials saves the config.json to Path with 0600 permissions.
+func (r *Registry) WriteDockerCredentials(dcr DockerCredentialsRequest) error {
+
+	config, err := r.DockerCredentials(dcr)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(dcr.Path, config, 0600)
+	if err != nil {
+		return errors.New("Unable to write docker credentials to " + dcr.Path + ". " + err.Error())
+	}
+	return nil
+}
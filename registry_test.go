@@ -0,0 +1,183 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestRegistry = godo.Registry{
+	Name:   "dog-registry",
+	Region: "fra1",
+}
+
+var TestRepository = godo.RepositoryV2{
+	RegistryName: "dog-registry",
+	Name:         "web",
+	TagCount:     1,
+}
+
+var TestRepositories = []*godo.RepositoryV2{&TestRepository}
+
+var TestRepositoryTag = godo.RepositoryTag{
+	RegistryName: "dog-registry",
+	Repository:   "web",
+	Tag:          "latest",
+}
+
+var TestRepositoryTags = []*godo.RepositoryTag{&TestRepositoryTag}
+
+var TestGarbageCollection = godo.GarbageCollection{
+	UUID:         "eff0feee-49c7-4e8f-ba5c-a320c109c8a8",
+	RegistryName: "dog-registry",
+	Status:       "requested",
+	Type:         godo.GCTypeUntaggedManifestsOnly,
+}
+
+var TestDockerConfig = []byte(`{"auths":{"registry.digitalocean.com":{"auth":"dG9rZW4="}}}`)
+
+func TestCreateRegistry(t *testing.T) {
+
+	rClient := NewRC(TestPAT)
+	rClient.client = &MockGodoRegistrySvc{}
+
+	expected := &TestRegistry
+	returned, _ := rClient.CreateRegistry(CreateRegistryRequest{Name: "dog-registry", SubscriptionTier: "basic", Region: FRA1})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetRepositories(t *testing.T) {
+
+	rClient := NewRC(TestPAT)
+	rClient.client = &MockGodoRegistrySvc{}
+
+	expected := TestRepositories
+	returned, _ := rClient.GetRepositories(FindRepositoriesRequest{Registry: "dog-registry"})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestGetRepositoryTags(t *testing.T) {
+
+	rClient := NewRC(TestPAT)
+	rClient.client = &MockGodoRegistrySvc{}
+
+	expected := TestRepositoryTags
+	returned, _ := rClient.GetRepositoryTags(FindRepositoryTagsRequest{Registry: "dog-registry", Repository: "web"})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteRepositoryTag(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		rClient := NewRC(TestPAT)
+		rClient.client = &MockGodoRegistrySvc{}
+
+		expectedError := "Unable to delete tag: latest in repository: web. Godo error: " + TestError
+		returnedError := rClient.DeleteRepositoryTag(DeleteRepositoryTagRequest{Registry: "dog-registry", Repository: "web", Tag: "latest"})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestStartGarbageCollection(t *testing.T) {
+
+	rClient := NewRC(TestPAT)
+	rClient.client = &MockGodoRegistrySvc{}
+
+	expected := &TestGarbageCollection
+	returned, _ := rClient.StartGarbageCollection(GarbageCollectionRequest{Registry: "dog-registry", GarbageCollectionKind: UntaggedManifestsOnly})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestWriteDockerCredentials(t *testing.T) {
+
+	mock := &MockGodoRegistrySvc{}
+	rClient := NewRC(TestPAT)
+	rClient.client = mock
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := rClient.WriteDockerCredentials(DockerCredentialsRequest{Expiry: time.Hour, Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	returned, _ := os.ReadFile(path)
+	if !reflect.DeepEqual(TestDockerConfig, returned) {
+		t.Errorf("expected %s\n , returned, %s\n ", TestDockerConfig, returned)
+	}
+	if mock.expirySeconds != 3600 {
+		t.Errorf("expected expiry of 3600 seconds, returned %d", mock.expirySeconds)
+	}
+
+}
+
+type MockGodoRegistrySvc struct {
+	expirySeconds int
+}
+
+func (m *MockGodoRegistrySvc) Create(context.Context, *godo.RegistryCreateRequest) (*godo.Registry, *godo.Response, error) {
+	return &TestRegistry, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) Get(context.Context) (*godo.Registry, *godo.Response, error) {
+	return &TestRegistry, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) Delete(context.Context) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoRegistrySvc) DockerCredentials(_ context.Context, request *godo.RegistryDockerCredentialsRequest) (*godo.DockerCredentials, *godo.Response, error) {
+	if request.ExpirySeconds != nil {
+		m.expirySeconds = *request.ExpirySeconds
+	}
+	return &godo.DockerCredentials{DockerConfigJSON: TestDockerConfig}, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) ListRepositoriesV2(context.Context, string, *godo.TokenListOptions) ([]*godo.RepositoryV2, *godo.Response, error) {
+	return TestRepositories, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) ListRepositoryTags(context.Context, string, string, *godo.ListOptions) ([]*godo.RepositoryTag, *godo.Response, error) {
+	return TestRepositoryTags, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) DeleteTag(context.Context, string, string, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoRegistrySvc) ListRepositoryManifests(context.Context, string, string, *godo.ListOptions) ([]*godo.RepositoryManifest, *godo.Response, error) {
+	return nil, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) DeleteManifest(context.Context, string, string, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoRegistrySvc) StartGarbageCollection(context.Context, string, ...*godo.StartGarbageCollectionRequest) (*godo.GarbageCollection, *godo.Response, error) {
+	return &TestGarbageCollection, nil, nil
+}
+
+func (m *MockGodoRegistrySvc) GetGarbageCollection(context.Context, string) (*godo.GarbageCollection, *godo.Response, error) {
+	return &TestGarbageCollection, nil, nil
+}
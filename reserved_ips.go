@@ -0,0 +1,217 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateReservedIPRequest struct {
+	Region
+	DropletID int
+	ProjectID string
+}
+
+type FindAllReservedIPsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type AssignReservedIPRequest struct {
+	IP        string
+	DropletID int
+}
+
+type UnassignReservedIPRequest struct {
+	IP string
+}
+
+type DeleteReservedIPRequest struct {
+	IP string
+}
+
+type FailoverRequest struct {
+	IP            string
+	FromDropletID int
+	ToDropletID   int
+	Timeout       time.Duration
+}
+
+type ReservedIPClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.ReservedIP, *godo.Response, error)
+	Get(context.Context, string) (*godo.ReservedIP, *godo.Response, error)
+	Create(context.Context, *godo.ReservedIPCreateRequest) (*godo.ReservedIP, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
+type ReservedIPActionClient interface {
+	Assign(context.Context, string, int) (*godo.Action, *godo.Response, error)
+	Unassign(context.Context, string) (*godo.Action, *godo.Response, error)
+	Get(context.Context, string, int) (*godo.Action, *godo.Response, error)
+}
+
+type ReservedIP struct {
+	client       ReservedIPClient
+	actions      ReservedIPActionClient
+	droplets     Droplet
+	pollInterval time.Duration
+}
+
+func NewRIPC(pat string) ReservedIP {
+	client := Authenticate(pat)
+	return ReservedIP{
+		client:       client.ReservedIPs,
+		actions:      client.ReservedIPActions,
+		droplets:     Droplet{client: client.Droplets},
+		pollInterval: 5 * time.Second,
+	}
+}
+
+func (r *ReservedIP) GetAllReservedIPs(farr FindAllReservedIPsRequest) ([]godo.ReservedIP, error) {
+
+	opt := &godo.ListOptions{
+		Page:    farr.Page,
+		PerPage: farr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	ips, _, err := r.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all reserved IPs. Godo error: " + err.Error())
+	}
+
+	return ips, nil
+}
+
+func (r *ReservedIP) GetReservedIP(ip string) (*godo.ReservedIP, error) {
+
+	ctx := context.TODO()
+
+	reserved, _, err := r.client.Get(ctx, ip)
+	if err != nil {
+		return nil, errors.New("Reserved IP: " + ip + ", was not found. Godo error: " + err.Error())
+	}
+
+	return reserved, nil
+}
+
+// CreateReservedIP reserves an IP in Region, or assigns it straight to
+// DropletID when one is given.
+func (r *ReservedIP) CreateReservedIP(crr CreateReservedIPRequest) (*godo.ReservedIP, error) {
+
+	create := &godo.ReservedIPCreateRequest{
+		DropletID: crr.DropletID,
+		ProjectID: crr.ProjectID,
+	}
+	if crr.DropletID == 0 {
+		create.Region = crr.Region.String()
+	}
+
+	ctx := context.TODO()
+
+	reserved, _, err := r.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create reserved IP. Godo error: " + err.Error())
+	}
+
+	return reserved, nil
+}
+
+func (r *ReservedIP) AssignReservedIP(arr AssignReservedIPRequest) (*godo.Action, error) {
+
+	ctx := context.TODO()
+
+	action, _, err := r.actions.Assign(ctx, arr.IP, arr.DropletID)
+	if err != nil {
+		return nil, errors.New("Unable to assign reserved IP: " + arr.IP + " to droplet with ID: " + strconv.Itoa(arr.DropletID) + ". Godo error: " + err.Error())
+	}
+
+	return action, nil
+}
+
+func (r *ReservedIP) UnassignReservedIP(urr UnassignReservedIPRequest) (*godo.Action, error) {
+
+	ctx := context.TODO()
+
+	action, _, err := r.actions.Unassign(ctx, urr.IP)
+	if err != nil {
+		return nil, errors.New("Unable to unassign reserved IP: " + urr.IP + ". Godo error: " + err.Error())
+	}
+
+	return action, nil
+}
+
+func (r *ReservedIP) DeleteReservedIP(drr DeleteReservedIPRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := r.client.Delete(ctx, drr.IP)
+	if err != nil {
+		return errors.New("Unable to delete reserved IP: " + drr.IP + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+// Failover moves a reserved IP from one droplet to another and blocks until
+// the assign action completes or Timeout passes.
+func (r *ReservedIP) Failover(fr FailoverRequest) (*godo.Action, error) {
+
+	reserved, err := r.GetReservedIP(fr.IP)
+	if err != nil {
+		return nil, err
+	}
+
+	if fr.FromDropletID != 0 && (reserved.Droplet == nil || reserved.Droplet.ID != fr.FromDropletID) {
+		return nil, errors.New("Reserved IP: " + fr.IP + " is not assigned to droplet with ID: " + strconv.Itoa(fr.FromDropletID))
+	}
+
+	target, err := r.droplets.GetDropletById(FindDropletByIDRequest{ID: fr.ToDropletID})
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved.Region != nil && target.Region != nil && target.Region.Slug != "" && reserved.Region.Slug != target.Region.Slug {
+		return nil, errors.New("Droplet with ID: " + strconv.Itoa(fr.ToDropletID) + " is in " + target.Region.Slug + " but reserved IP: " + fr.IP + " is in " + reserved.Region.Slug)
+	}
+
+	action, err := r.AssignReservedIP(AssignReservedIPRequest{IP: fr.IP, DropletID: fr.ToDropletID})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.waitForAction(fr.IP, action, fr.Timeout)
+}
+
+func (r *ReservedIP) waitForAction(ip string, action *godo.Action, timeout time.Duration) (*godo.Action, error) {
+
+	ctx := context.TODO()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for action.Status == godo.ActionInProgress {
+		select {
+		case <-ctx.Done():
+			return action, errors.New("Timed out waiting for action with ID: " + strconv.Itoa(action.ID) + " on reserved IP: " + ip)
+		case <-time.After(r.pollInterval):
+		}
+
+		next, _, err := r.actions.Get(ctx, ip, action.ID)
+		if err != nil {
+			return action, errors.New("Unable to get action with ID: " + strconv.Itoa(action.ID) + ". Godo error: " + err.Error())
+		}
+		action = next
+	}
+
+	if action.Status != godo.ActionCompleted {
+		return action, errors.New("Action with ID: " + strconv.Itoa(action.ID) + " on reserved IP: " + ip + " finished with status: " + action.Status)
+	}
+
+	return action, nil
+}
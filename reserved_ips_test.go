@@ -0,0 +1,146 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestReservedIP = godo.ReservedIP{
+	IP:      "45.55.96.47",
+	Region:  &godo.Region{Slug: "nyc3"},
+	Droplet: &godo.Droplet{ID: 1},
+}
+
+var TestReservedIPs = []godo.ReservedIP{TestReservedIP}
+
+var TestInProgressAction = godo.Action{
+	ID:     10,
+	Status: godo.ActionInProgress,
+	Type:   "assign_ip",
+}
+
+var TestCompletedAction = godo.Action{
+	ID:     10,
+	Status: godo.ActionCompleted,
+	Type:   "assign_ip",
+}
+
+var TestFailoverRequest = FailoverRequest{
+	IP:            "45.55.96.47",
+	FromDropletID: 1,
+	ToDropletID:   2,
+	Timeout:       time.Second,
+}
+
+func newTestReservedIP() ReservedIP {
+	ripClient := NewRIPC(TestPAT)
+	ripClient.client = &MockGodoReservedIPSvc{}
+	ripClient.actions = &MockGodoReservedIPActionSvc{}
+	ripClient.droplets.client = &MockGodoDropletSvc{}
+	ripClient.pollInterval = time.Millisecond
+	return ripClient
+}
+
+func TestGetAllReservedIPs(t *testing.T) {
+
+	ripClient := newTestReservedIP()
+
+	expected := TestReservedIPs
+	returned, _ := ripClient.GetAllReservedIPs(FindAllReservedIPsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateReservedIP(t *testing.T) {
+
+	ripClient := newTestReservedIP()
+
+	expected := &TestReservedIP
+	returned, _ := ripClient.CreateReservedIP(CreateReservedIPRequest{Region: NYC3})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteReservedIP(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		ripClient := newTestReservedIP()
+
+		expectedError := "Unable to delete reserved IP: 45.55.96.47. Godo error: " + TestError
+		returnedError := ripClient.DeleteReservedIP(DeleteReservedIPRequest{IP: "45.55.96.47"})
+		if expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestFailover(t *testing.T) {
+
+	t.Run("Action completes", func(t *testing.T) {
+		ripClient := newTestReservedIP()
+
+		expected := &TestCompletedAction
+		returned, err := ripClient.Failover(TestFailoverRequest)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+		}
+	})
+
+	t.Run("Error is thrown when IP is on another droplet", func(t *testing.T) {
+		ripClient := newTestReservedIP()
+
+		request := TestFailoverRequest
+		request.FromDropletID = 3
+		expectedError := "Reserved IP: 45.55.96.47 is not assigned to droplet with ID: 3"
+		_, returnedError := ripClient.Failover(request)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoReservedIPSvc struct{}
+
+func (m *MockGodoReservedIPSvc) List(context.Context, *godo.ListOptions) ([]godo.ReservedIP, *godo.Response, error) {
+	return TestReservedIPs, nil, nil
+}
+
+func (m *MockGodoReservedIPSvc) Get(context.Context, string) (*godo.ReservedIP, *godo.Response, error) {
+	return &TestReservedIP, nil, nil
+}
+
+func (m *MockGodoReservedIPSvc) Create(context.Context, *godo.ReservedIPCreateRequest) (*godo.ReservedIP, *godo.Response, error) {
+	return &TestReservedIP, nil, nil
+}
+
+func (m *MockGodoReservedIPSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+type MockGodoReservedIPActionSvc struct{}
+
+func (m *MockGodoReservedIPActionSvc) Assign(context.Context, string, int) (*godo.Action, *godo.Response, error) {
+	return &TestInProgressAction, nil, nil
+}
+
+func (m *MockGodoReservedIPActionSvc) Unassign(context.Context, string) (*godo.Action, *godo.Response, error) {
+	return &TestInProgressAction, nil, nil
+}
+
+func (m *MockGodoReservedIPActionSvc) Get(context.Context, string, int) (*godo.Action, *godo.Response, error) {
+	return &TestCompletedAction, nil, nil
+}
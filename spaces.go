@@ -0,0 +1,316 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+type CreateBucketRequest struct {
+	Name string
+}
+
+type DeleteBucketRequest struct {
+	Name string
+}
+
+type UploadObjectRequest struct {
+	Bucket      string
+	Key         string
+	Path        string
+	ContentType string
+	Public      bool
+}
+
+type PutObjectRequest struct {
+	Bucket      string
+	Key         string
+	Body        io.Reader
+	Size        int64
+	ContentType string
+	Public      bool
+}
+
+type DownloadObjectRequest struct {
+	Bucket string
+	Key    string
+	Path   string
+}
+
+type DeleteObjectRequest struct {
+	Bucket string
+	Key    string
+}
+
+type PresignRequest struct {
+	Bucket  string
+	Key     string
+	Expires time.Duration
+	Upload  bool
+}
+
+type CORSRule struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	MaxAgeSeconds  int
+}
+
+type SetCORSRequest struct {
+	Bucket string
+	Rules  []CORSRule
+}
+
+// LifecycleRule expires objects under Prefix after ExpireAfterDays and
+// aborts multipart uploads left incomplete for AbortUploadsAfterDays.
+type LifecycleRule struct {
+	ID                    string
+	Prefix                string
+	ExpireAfterDays       int
+	AbortUploadsAfterDays int
+}
+
+type SetLifecycleRequest struct {
+	Bucket string
+	Rules  []LifecycleRule
+}
+
+type SpacesClient interface {
+	MakeBucket(context.Context, string, minio.MakeBucketOptions) error
+	RemoveBucket(context.Context, string) error
+	ListBuckets(context.Context) ([]minio.BucketInfo, error)
+	FPutObject(context.Context, string, string, string, minio.PutObjectOptions) (minio.UploadInfo, error)
+	PutObject(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) (minio.UploadInfo, error)
+	FGetObject(context.Context, string, string, string, minio.GetObjectOptions) error
+	RemoveObject(context.Context, string, string, minio.RemoveObjectOptions) error
+	PresignedGetObject(context.Context, string, string, time.Duration, url.Values) (*url.URL, error)
+	PresignedPutObject(context.Context, string, string, time.Duration) (*url.URL, error)
+	SetBucketCors(context.Context, string, *cors.Config) error
+	GetBucketCors(context.Context, string) (*cors.Config, error)
+	SetBucketLifecycle(context.Context, string, *lifecycle.Configuration) error
+	GetBucketLifecycle(context.Context, string) (*lifecycle.Configuration, error)
+}
+
+type Spaces struct {
+	client SpacesClient
+	region Region
+}
+
+func NewSC(sc SpacesCredentials, region Region) (Spaces, error) {
+	client, err := AuthenticateSpaces(sc, region)
+	if err != nil {
+		return Spaces{}, errors.New("Unable to create spaces client. " + err.Error())
+	}
+	return Spaces{client: client, region: region}, nil
+}
+
+func (s *Spaces) GetAllBuckets() ([]minio.BucketInfo, error) {
+
+	ctx := context.TODO()
+
+	buckets, err := s.client.ListBuckets(ctx)
+	if err != nil {
+		return nil, errors.New("Unable to get all buckets. Spaces error: " + err.Error())
+	}
+
+	return buckets, nil
+}
+
+func (s *Spaces) CreateBucket(cbr CreateBucketRequest) error {
+
+	ctx := context.TODO()
+
+	err := s.client.MakeBucket(ctx, cbr.Name, minio.MakeBucketOptions{Region: s.region.String()})
+	if err != nil {
+		return errors.New("Unable to create bucket: " + cbr.Name + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+func (s *Spaces) DeleteBucket(dbr DeleteBucketRequest) error {
+
+	ctx := context.TODO()
+
+	err := s.client.RemoveBucket(ctx, dbr.Name)
+	if err != nil {
+		return errors.New("Unable to delete bucket: " + dbr.Name + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+func (s *Spaces) UploadObject(uor UploadObjectRequest) (*minio.UploadInfo, error) {
+
+	ctx := context.TODO()
+
+	info, err := s.client.FPutObject(ctx, uor.Bucket, uor.Key, uor.Path, putObjectOptions(uor.ContentType, uor.Public))
+	if err != nil {
+		return nil, errors.New("Unable to upload " + uor.Path + " to " + uor.Bucket + "/" + uor.Key + ". Spaces error: " + err.Error())
+	}
+
+	return &info, nil
+}
+
+func (s *Spaces) PutObject(por PutObjectRequest) (*minio.UploadInfo, error) {
+
+	ctx := context.TODO()
+
+	info, err := s.client.PutObject(ctx, por.Bucket, por.Key, por.Body, por.Size, putObjectOptions(por.ContentType, por.Public))
+	if err != nil {
+		return nil, errors.New("Unable to put object " + por.Bucket + "/" + por.Key + ". Spaces error: " + err.Error())
+	}
+
+	return &info, nil
+}
+
+func (s *Spaces) DownloadObject(dor DownloadObjectRequest) error {
+
+	ctx := context.TODO()
+
+	err := s.client.FGetObject(ctx, dor.Bucket, dor.Key, dor.Path, minio.GetObjectOptions{})
+	if err != nil {
+		return errors.New("Unable to download " + dor.Bucket + "/" + dor.Key + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+func (s *Spaces) DeleteObject(dor DeleteObjectRequest) error {
+
+	ctx := context.TODO()
+
+	err := s.client.RemoveObject(ctx, dor.Bucket, dor.Key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return errors.New("Unable to delete object " + dor.Bucket + "/" + dor.Key + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+// PresignURL returns a URL that downloads the object, or uploads it when
+// Upload is set, without credentials until Expires passes.
+func (s *Spaces) PresignURL(pr PresignRequest) (*url.URL, error) {
+
+	ctx := context.TODO()
+
+	var presigned *url.URL
+	var err error
+	if pr.Upload {
+		presigned, err = s.client.PresignedPutObject(ctx, pr.Bucket, pr.Key, pr.Expires)
+	} else {
+		presigned, err = s.client.PresignedGetObject(ctx, pr.Bucket, pr.Key, pr.Expires, nil)
+	}
+	if err != nil {
+		return nil, errors.New("Unable to presign URL for " + pr.Bucket + "/" + pr.Key + ". Spaces error: " + err.Error())
+	}
+
+	return presigned, nil
+}
+
+func (s *Spaces) SetCORS(scr SetCORSRequest) error {
+
+	rules := make([]cors.Rule, len(scr.Rules))
+	for i, rule := range scr.Rules {
+		rules[i] = cors.Rule{
+			AllowedOrigin: rule.AllowedOrigins,
+			AllowedMethod: rule.AllowedMethods,
+			AllowedHeader: rule.AllowedHeaders,
+			MaxAgeSeconds: rule.MaxAgeSeconds,
+		}
+	}
+
+	ctx := context.TODO()
+
+	err := s.client.SetBucketCors(ctx, scr.Bucket, cors.NewConfig(rules))
+	if err != nil {
+		return errors.New("Unable to set CORS rules on bucket: " + scr.Bucket + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+func (s *Spaces) GetCORS(bucket string) ([]CORSRule, error) {
+
+	ctx := context.TODO()
+
+	config, err := s.client.GetBucketCors(ctx, bucket)
+	if err != nil {
+		return nil, errors.New("Unable to get CORS rules on bucket: " + bucket + ". Spaces error: " + err.Error())
+	}
+
+	var rules []CORSRule
+	if config != nil {
+		for _, rule := range config.CORSRules {
+			rules = append(rules, CORSRule{
+				AllowedOrigins: rule.AllowedOrigin,
+				AllowedMethods: rule.AllowedMethod,
+				AllowedHeaders: rule.AllowedHeader,
+				MaxAgeSeconds:  rule.MaxAgeSeconds,
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+func (s *Spaces) SetLifecycle(slr SetLifecycleRequest) error {
+
+	config := lifecycle.NewConfiguration()
+	for _, rule := range slr.Rules {
+		config.Rules = append(config.Rules, lifecycle.Rule{
+			ID:         rule.ID,
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: rule.Prefix},
+			Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(rule.ExpireAfterDays)},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: lifecycle.ExpirationDays(rule.AbortUploadsAfterDays),
+			},
+		})
+	}
+
+	ctx := context.TODO()
+
+	err := s.client.SetBucketLifecycle(ctx, slr.Bucket, config)
+	if err != nil {
+		return errors.New("Unable to set lifecycle rules on bucket: " + slr.Bucket + ". Spaces error: " + err.Error())
+	}
+	return nil
+}
+
+func (s *Spaces) GetLifecycle(bucket string) ([]LifecycleRule, error) {
+
+	ctx := context.TODO()
+
+	config, err := s.client.GetBucketLifecycle(ctx, bucket)
+	if err != nil {
+		return nil, errors.New("Unable to get lifecycle rules on bucket: " + bucket + ". Spaces error: " + err.Error())
+	}
+
+	var rules []LifecycleRule
+	if config != nil {
+		for _, rule := range config.Rules {
+			prefix := rule.RuleFilter.Prefix
+			if prefix == "" {
+				prefix = rule.Prefix
+			}
+			rules = append(rules, LifecycleRule{
+				ID:                    rule.ID,
+				Prefix:                prefix,
+				ExpireAfterDays:       int(rule.Expiration.Days),
+				AbortUploadsAfterDays: int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation),
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+func putObjectOptions(contentType string, public bool) minio.PutObjectOptions {
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	if public {
+		opts.UserMetadata = map[string]string{"x-amz-acl": "public-read"}
+	}
+	return opts
+}
@@ -0,0 +1,210 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+var TestSpacesCredentials = SpacesCredentials{
+	AccessKey: "TestAccessKey",
+	SecretKey: "TestSecretKey",
+}
+
+var TestBuckets = []minio.BucketInfo{{Name: "dog-artifacts"}}
+
+var TestCORSRules = []CORSRule{
+	{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{"GET"},
+		MaxAgeSeconds:  3000,
+	},
+}
+
+var TestLifecycleRules = []LifecycleRule{
+	{
+		ID:              "expire-dumps",
+		Prefix:          "dumps/",
+		ExpireAfterDays: 30,
+	},
+}
+
+func TestGetAllBuckets(t *testing.T) {
+
+	sClient, _ := NewSC(TestSpacesCredentials, NYC3)
+	sClient.client = &MockSpacesSvc{}
+
+	expected := TestBuckets
+	returned, _ := sClient.GetAllBuckets()
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteBucket(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		sClient, _ := NewSC(TestSpacesCredentials, NYC3)
+		sClient.client = &MockSpacesSvc{}
+
+		expectedError := "Unable to delete bucket: dog-artifacts. Spaces error: " + TestError
+		returnedError := sClient.DeleteBucket(DeleteBucketRequest{Name: "dog-artifacts"})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestCORS(t *testing.T) {
+
+	mock := &MockSpacesSvc{}
+	sClient, _ := NewSC(TestSpacesCredentials, NYC3)
+	sClient.client = mock
+
+	err := sClient.SetCORS(SetCORSRequest{Bucket: "dog-artifacts", Rules: TestCORSRules})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	returned, _ := sClient.GetCORS("dog-artifacts")
+	if !reflect.DeepEqual(TestCORSRules, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", TestCORSRules, returned)
+	}
+
+}
+
+func TestLifecycle(t *testing.T) {
+
+	mock := &MockSpacesSvc{}
+	sClient, _ := NewSC(TestSpacesCredentials, NYC3)
+	sClient.client = mock
+
+	err := sClient.SetLifecycle(SetLifecycleRequest{Bucket: "dog-artifacts", Rules: TestLifecycleRules})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	returned, _ := sClient.GetLifecycle("dog-artifacts")
+	if !reflect.DeepEqual(TestLifecycleRules, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", TestLifecycleRules, returned)
+	}
+
+}
+
+func TestPresignURL(t *testing.T) {
+
+	credentials := TestSpacesCredentials
+	credentials.Endpoint = "localhost:9000"
+	credentials.Insecure = true
+	sClient, _ := NewSC(credentials, NYC3)
+
+	returned, err := sClient.PresignURL(PresignRequest{Bucket: "dog-artifacts", Key: "bootstrap.sh", Expires: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if returned.Host != "localhost:9000" || returned.Path != "/dog-artifacts/bootstrap.sh" || returned.Query().Get("X-Amz-Expires") != "3600" {
+		t.Errorf("unexpected presigned URL: %s", returned)
+	}
+
+}
+
+func TestPutObjectToStandIn(t *testing.T) {
+
+	var received, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received = r.Method + " " + r.URL.Path
+		body = string(data)
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+	}))
+	defer server.Close()
+
+	credentials := TestSpacesCredentials
+	credentials.Endpoint = strings.TrimPrefix(server.URL, "http://")
+	credentials.Insecure = true
+	sClient, _ := NewSC(credentials, NYC3)
+
+	_, err := sClient.PutObject(PutObjectRequest{Bucket: "dog-artifacts", Key: "dump.sql", Body: strings.NewReader("select 1;"), Size: 9})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "PUT /dog-artifacts/dump.sql"; received != expected {
+		t.Errorf("expected %s\n , received, %s\n ", expected, received)
+	}
+	if !strings.Contains(body, "select 1;") {
+		t.Errorf("expected body to contain the object, received %s\n", body)
+	}
+
+}
+
+type MockSpacesSvc struct {
+	cors      *cors.Config
+	lifecycle *lifecycle.Configuration
+}
+
+func (m *MockSpacesSvc) MakeBucket(context.Context, string, minio.MakeBucketOptions) error {
+	return nil
+}
+
+func (m *MockSpacesSvc) RemoveBucket(context.Context, string) error {
+	return errors.New(TestError)
+}
+
+func (m *MockSpacesSvc) ListBuckets(context.Context) ([]minio.BucketInfo, error) {
+	return TestBuckets, nil
+}
+
+func (m *MockSpacesSvc) FPutObject(context.Context, string, string, string, minio.PutObjectOptions) (minio.UploadInfo, error) {
+	return minio.UploadInfo{}, nil
+}
+
+func (m *MockSpacesSvc) PutObject(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) (minio.UploadInfo, error) {
+	return minio.UploadInfo{}, nil
+}
+
+func (m *MockSpacesSvc) FGetObject(context.Context, string, string, string, minio.GetObjectOptions) error {
+	return nil
+}
+
+func (m *MockSpacesSvc) RemoveObject(context.Context, string, string, minio.RemoveObjectOptions) error {
+	return errors.New(TestError)
+}
+
+func (m *MockSpacesSvc) PresignedGetObject(context.Context, string, string, time.Duration, url.Values) (*url.URL, error) {
+	return &url.URL{}, nil
+}
+
+func (m *MockSpacesSvc) PresignedPutObject(context.Context, string, string, time.Duration) (*url.URL, error) {
+	return &url.URL{}, nil
+}
+
+func (m *MockSpacesSvc) SetBucketCors(_ context.Context, _ string, config *cors.Config) error {
+	m.cors = config
+	return nil
+}
+
+func (m *MockSpacesSvc) GetBucketCors(context.Context, string) (*cors.Config, error) {
+	return m.cors, nil
+}
+
+func (m *MockSpacesSvc) SetBucketLifecycle(_ context.Context, _ string, config *lifecycle.Configuration) error {
+	m.lifecycle = config
+	return nil
+}
+
+func (m *MockSpacesSvc) GetBucketLifecycle(context.Context, string) (*lifecycle.Configuration, error) {
+	return m.lifecycle, nil
+}
@@ -0,0 +1,633 @@
+package dog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes the droplets and database clusters an account should have.
+// Resources are matched to live ones by name.
+type Spec struct {
+	Droplets  []CreateDropletRequest
+	Databases []DatabaseClusterSpec
+}
+
+// DatabaseClusterSpec is a database cluster in a Spec. The maintenance window
+// is left as it is when MaintenanceWindow is nil. DBNames, including the
+// default database, is only checked for drift and never applied.
+type DatabaseClusterSpec struct {
+	CreateDatabaseClusterRequest
+	MaintenanceWindow *MaintenanceWindow
+	DBNames           []string
+}
+
+type MaintenanceWindow struct {
+	Day  string
+	Time string
+}
+
+// PlanRequest plans the changes that make the account match Spec. Live
+// droplets and clusters missing from Spec are only deleted with Prune.
+type PlanRequest struct {
+	Spec
+	Prune bool
+}
+
+// Change kinds
+type ChangeKind int
+
+const (
+	CreateChange ChangeKind = iota
+	ResizeChange
+	MigrateChange
+	MaintenanceChange
+	DeleteChange
+)
+
+func (ck ChangeKind) String() string {
+	names := [...]string{
+		"create",
+		"resize",
+		"migrate",
+		"maintenance",
+		"delete",
+	}
+	if ck < CreateChange || ck > DeleteChange {
+		return "That is not a change kind"
+	}
+	return names[ck]
+}
+
+func (ck ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(ck.String()), nil
+}
+
+const (
+	dropletResource  = "droplet"
+	databaseResource = "database cluster"
+)
+
+// Change is one step of a Plan. From and To describe what is changed, and ID
+// is empty for resources that are yet to be created.
+type Change struct {
+	Kind     ChangeKind
+	Resource string
+	Name     string
+	ID       string `json:",omitempty"`
+	From     string `json:",omitempty"`
+	To       string `json:",omitempty"`
+
+	droplet  *CreateDropletRequest
+	database *DatabaseClusterSpec
+}
+
+// Plan is the changes Apply makes, in order: creates, then changes to
+// existing database clusters, then deletes. The API rejects changes to a
+// cluster that is not online, such as while it migrates, so those are
+// Deferred to a later plan.
+type Plan struct {
+	Changes  []Change
+	Deferred []Change `json:",omitempty"`
+}
+
+// Infrastructure plans and applies Specs.
+type Infrastructure struct {
+	droplets  Droplet
+	databases Database
+}
+
+func NewIC(pat string, opts ...Option) Infrastructure {
+	return Infrastructure{droplets: NewDC(pat, opts...), databases: NewDBC(pat, opts...)}
+}
+
+// String prints the plan as a diff, with a line per change and a summary.
+func (p *Plan) String() string {
+
+	if len(p.Changes) == 0 && len(p.Deferred) == 0 {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	creates, changes, deletes := 0, 0, 0
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case CreateChange:
+			creates++
+			b.WriteString("+ " + c.Resource + " " + c.Name + " (" + c.To + ")\n")
+		case DeleteChange:
+			deletes++
+			b.WriteString("- " + c.Resource + " " + c.Name + " (" + c.ID + ")\n")
+		default:
+			changes++
+			b.WriteString("~ " + c.Resource + " " + c.Name + ": " + c.Kind.String() + " " + c.From + " -> " + c.To + "\n")
+		}
+	}
+	for _, c := range p.Deferred {
+		b.WriteString("  " + c.Resource + " " + c.Name + ": " + c.Kind.String() + " " + c.From + " -> " + c.To + " waits until the cluster is online, plan again then\n")
+	}
+	b.WriteString("\nPlan: " + strconv.Itoa(creates) + " to create, " + strconv.Itoa(changes) + " to change, " + strconv.Itoa(deletes) + " to delete.\n")
+
+	return b.String()
+}
+
+// Plan compares the spec with the live droplets and database clusters.
+// Droplets cannot be resized or moved, so existing droplets are left alone
+// even where they differ from the spec.
+func (i *Infrastructure) Plan(pr PlanRequest) (*Plan, error) {
+
+	ctx := context.TODO()
+
+	if err := validateSpec(pr.Spec); err != nil {
+		return nil, err
+	}
+
+	droplets, err := i.allDroplets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := i.allClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liveDroplets := map[string][]godo.Droplet{}
+	for _, droplet := range droplets {
+		liveDroplets[droplet.Name] = append(liveDroplets[droplet.Name], droplet)
+	}
+	liveClusters := map[string][]godo.Database{}
+	for _, cluster := range clusters {
+		liveClusters[cluster.Name] = append(liveClusters[cluster.Name], cluster)
+	}
+
+	var creates, changes, deletes, deferred []Change
+
+	wanted := map[string]bool{}
+	for n := range pr.Droplets {
+		want := &pr.Droplets[n]
+		wanted[want.Name] = true
+		if len(liveDroplets[want.Name]) > 0 {
+			continue
+		}
+		creates = append(creates, Change{
+			Kind:     CreateChange,
+			Resource: dropletResource,
+			Name:     want.Name,
+			To:       want.Region.String() + ", " + want.DropletSize.String() + ", " + want.Image,
+			droplet:  want,
+		})
+	}
+
+	for n := range pr.Databases {
+		want := &pr.Databases[n]
+		live := liveClusters[want.Name]
+		if len(live) == 0 {
+			creates = append(creates, Change{
+				Kind:     CreateChange,
+				Resource: databaseResource,
+				Name:     want.Name,
+				To:       clusterDescription(want.DatabaseType.String(), want.Version, want.Region.String(), want.DatabaseSize.String(), want.NumNodes),
+				database: want,
+			})
+			continue
+		}
+		if len(live) > 1 {
+			return nil, errors.New("More than one database cluster is named " + want.Name + ". Rename all but one to plan it")
+		}
+
+		have := live[0]
+		if have.EngineSlug != want.DatabaseType.String() {
+			return nil, errors.New("Database cluster " + want.Name + " is " + have.EngineSlug + ", not " + want.DatabaseType.String() + ". Engines cannot be changed in place")
+		}
+
+		// a cluster takes no changes until it is online, including after a
+		// migration planned here, and keeps its old region while it migrates
+		planned := &changes
+		if have.Status != "online" {
+			planned = &deferred
+		}
+		change := Change{Resource: databaseResource, Name: want.Name, ID: have.ID, database: want}
+		if have.RegionSlug != want.Region.String() && have.Status != "migrating" {
+			change.Kind, change.From, change.To = MigrateChange, have.RegionSlug, want.Region.String()
+			*planned = append(*planned, change)
+			planned = &deferred
+		}
+		if have.SizeSlug != want.DatabaseSize.String() || have.NumNodes != want.NumNodes {
+			change.Kind = ResizeChange
+			change.From = have.SizeSlug + " x" + strconv.Itoa(have.NumNodes)
+			change.To = want.DatabaseSize.String() + " x" + strconv.Itoa(want.NumNodes)
+			*planned = append(*planned, change)
+		}
+		if want.MaintenanceWindow != nil && !sameWindow(have.MaintenanceWindow, want.MaintenanceWindow) {
+			change.Kind, change.From, change.To = MaintenanceChange, "none", want.MaintenanceWindow.Day+" "+want.MaintenanceWindow.Time
+			if have.MaintenanceWindow != nil {
+				change.From = have.MaintenanceWindow.Day + " " + have.MaintenanceWindow.Hour
+			}
+			*planned = append(*planned, change)
+		}
+	}
+
+	if pr.Prune {
+		for _, droplet := range droplets {
+			if !wanted[droplet.Name] {
+				deletes = append(deletes, Change{Kind: DeleteChange, Resource: dropletResource, Name: droplet.Name, ID: strconv.Itoa(droplet.ID)})
+			}
+		}
+		wantedClusters := map[string]bool{}
+		for _, want := range pr.Databases {
+			wantedClusters[want.Name] = true
+		}
+		for _, cluster := range clusters {
+			if !wantedClusters[cluster.Name] {
+				deletes = append(deletes, Change{Kind: DeleteChange, Resource: databaseResource, Name: cluster.Name, ID: cluster.ID})
+			}
+		}
+	}
+
+	plan := &Plan{}
+	plan.Changes = append(plan.Changes, creates...)
+	plan.Changes = append(plan.Changes, changes...)
+	plan.Changes = append(plan.Changes, deletes...)
+	plan.Deferred = deferred
+	return plan, nil
+}
+
+// Apply makes the changes in plan, stopping at the first that fails.
+func (i *Infrastructure) Apply(plan *Plan) error {
+
+	for n, c := range plan.Changes {
+		if err := i.apply(c); err != nil {
+			return errors.New("Unable to apply change " + strconv.Itoa(n+1) + " of " + strconv.Itoa(len(plan.Changes)) + ". " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (i *Infrastructure) apply(c Change) error {
+
+	switch {
+	case c.Kind == CreateChange && c.droplet != nil:
+		_, err := i.droplets.CreateDroplet(*c.droplet)
+		return err
+
+	case c.Kind == CreateChange && c.database != nil:
+		cluster, err := i.databases.Create(c.database.CreateDatabaseClusterRequest)
+		if err != nil {
+			return err
+		}
+		if c.database.MaintenanceWindow == nil {
+			return nil
+		}
+		return i.databases.ConfigureMaintenanceWindow(UpdateMaintenanceWindowRequest{Id: cluster.ID, Day: c.database.MaintenanceWindow.Day, Time: c.database.MaintenanceWindow.Time})
+
+	case c.Kind == MigrateChange:
+		return i.databases.MigrateToNewRegion(MigrateRegionRequest{Id: c.ID, Region: c.database.Region})
+
+	case c.Kind == ResizeChange:
+		return i.databases.ResizeCluster(ResizeClusterRequest{Id: c.ID, DatabaseSize: c.database.DatabaseSize, NumNodes: c.database.NumNodes})
+
+	case c.Kind == MaintenanceChange:
+		return i.databases.ConfigureMaintenanceWindow(UpdateMaintenanceWindowRequest{Id: c.ID, Day: c.database.MaintenanceWindow.Day, Time: c.database.MaintenanceWindow.Time})
+
+	case c.Kind == DeleteChange && c.Resource == dropletResource:
+		id, err := strconv.Atoi(c.ID)
+		if err != nil {
+			return errors.New("Droplet ID " + c.ID + " is not a number")
+		}
+		return i.droplets.DeleteDroplet(DeleteDropletRequest{ID: id})
+
+	case c.Kind == DeleteChange && c.Resource == databaseResource:
+		return i.databases.DeleteCluster(c.ID)
+	}
+
+	return errors.New("Unable to " + c.Kind.String() + " " + c.Resource + " " + c.Name)
+}
+
+func (i *Infrastructure) allDroplets(ctx context.Context) ([]godo.Droplet, error) {
+	var droplets []godo.Droplet
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		pageCtx, op := i.droplets.observer.begin(ctx, "Droplets.List", slog.Int("page", opt.Page))
+		page, resp, err := i.droplets.client.List(pageCtx, opt)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to get all droplets. Godo error: " + err.Error())
+		}
+		droplets = append(droplets, page...)
+		if isLastPage(resp) {
+			return droplets, nil
+		}
+		opt.Page++
+	}
+}
+
+func (i *Infrastructure) allClusters(ctx context.Context) ([]godo.Database, error) {
+	var clusters []godo.Database
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		pageCtx, op := i.databases.observer.begin(ctx, "Databases.List", slog.Int("page", opt.Page))
+		page, resp, err := i.databases.client.List(pageCtx, opt)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to get all database clusters. Godo error: " + err.Error())
+		}
+		clusters = append(clusters, page...)
+		if isLastPage(resp) {
+			return clusters, nil
+		}
+		opt.Page++
+	}
+}
+
+func validateSpec(spec Spec) error {
+
+	names := map[string]bool{}
+	for _, droplet := range spec.Droplets {
+		if droplet.Name == "" {
+			return errors.New("Every droplet in the spec needs a name")
+		}
+		if names[droplet.Name] {
+			return errors.New("Droplet " + droplet.Name + " is in the spec more than once")
+		}
+		names[droplet.Name] = true
+	}
+
+	names = map[string]bool{}
+	for _, cluster := range spec.Databases {
+		if cluster.Name == "" {
+			return errors.New("Every database cluster in the spec needs a name")
+		}
+		if names[cluster.Name] {
+			return errors.New("Database cluster " + cluster.Name + " is in the spec more than once")
+		}
+		names[cluster.Name] = true
+	}
+
+	return nil
+}
+
+func clusterDescription(engine string, version string, region string, size string, nodes int) string {
+	if version != "" {
+		engine += " " + version
+	}
+	return engine + ", " + region + ", " + size + " x" + strconv.Itoa(nodes)
+}
+
+// sameWindow compares windows ignoring the seconds the API adds to the hour.
+func sameWindow(have *godo.DatabaseMaintenanceWindow, want *MaintenanceWindow) bool {
+	if have == nil {
+		return false
+	}
+	return strings.EqualFold(have.Day, want.Day) && (have.Hour == want.Time || strings.HasPrefix(have.Hour, want.Time+":"))
+}
+
+// Spec files
+
+type specFile struct {
+	Droplets  []dropletSpecFile  `json:"droplets,omitempty" yaml:"droplets,omitempty"`
+	Databases []databaseSpecFile `json:"databases,omitempty" yaml:"databases,omitempty"`
+}
+
+type dropletSpecFile struct {
+	Name              string   `json:"name" yaml:"name"`
+	Region            string   `json:"region" yaml:"region"`
+	Size              string   `json:"size" yaml:"size"`
+	Image             string   `json:"image" yaml:"image"`
+	SSHKeys           []int    `json:"ssh_keys,omitempty" yaml:"ssh_keys,omitempty"`
+	Backups           bool     `json:"backups,omitempty" yaml:"backups,omitempty"`
+	IPv6              bool     `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+	UserData          string   `json:"user_data,omitempty" yaml:"user_data,omitempty"`
+	PrivateNetworking bool     `json:"private_networking,omitempty" yaml:"private_networking,omitempty"`
+	Volumes           []string `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	Tags              []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	VPCUUID           string   `json:"vpc_uuid,omitempty" yaml:"vpc_uuid,omitempty"`
+	Monitoring        bool     `json:"monitoring,omitempty" yaml:"monitoring,omitempty"`
+	VPC               string   `json:"vpc,omitempty" yaml:"vpc,omitempty"`
+	Project           string   `json:"project,omitempty" yaml:"project,omitempty"`
+}
+
+type databaseSpecFile struct {
+	Name              string             `json:"name" yaml:"name"`
+	Engine            string             `json:"engine" yaml:"engine"`
+	Version           string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Size              string             `json:"size" yaml:"size"`
+	Region            string             `json:"region" yaml:"region"`
+	NumNodes          int                `json:"num_nodes,omitempty" yaml:"num_nodes,omitempty"`
+	Tags              []string           `json:"tags,omitempty" yaml:"tags,omitempty"`
+	VPC               string             `json:"vpc,omitempty" yaml:"vpc,omitempty"`
+	Project           string             `json:"project,omitempty" yaml:"project,omitempty"`
+	MaintenanceWindow *maintenanceWindow `json:"maintenance_window,omitempty" yaml:"maintenance_window,omitempty"`
+	DBNames           []string           `json:"dbs,omitempty" yaml:"dbs,omitempty"`
+}
+
+type maintenanceWindow struct {
+	Day  string `json:"day" yaml:"day"`
+	Hour string `json:"hour" yaml:"hour"`
+}
+
+// LoadSpec reads a spec from a YAML or JSON file, with regions and sizes
+// given as slugs:
+//
+//	droplets:
+//	  - name: web
+//	    region: nyc3
+//	    size: s-1vcpu-1gb
+//	    image: ubuntu-22-04-x64
+//	databases:
+//	  - name: orders
+//	    engine: pg
+//	    size: db-s-1vcpu-1gb
+//	    region: nyc3
+//	    num_nodes: 1
+//	    maintenance_window: {day: tuesday, hour: "14:00"}
+//	    dbs: [defaultdb, reports]
+func LoadSpec(path string) (*Spec, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("Unable to read spec " + path + ". " + err.Error())
+	}
+
+	// as with app specs, YAML is decoded generically and handed to the JSON tags
+	var raw interface{}
+	err = yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, errors.New("Unable to parse spec " + path + ". " + err.Error())
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.New("Unable to parse spec " + path + ". " + err.Error())
+	}
+
+	var file specFile
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&file)
+	if err != nil {
+		return nil, errors.New("Unable to parse spec " + path + ". " + err.Error())
+	}
+
+	spec, err := file.spec()
+	if err != nil {
+		return nil, errors.New("Unable to parse spec " + path + ". " + err.Error())
+	}
+
+	return spec, nil
+}
+
+func (f specFile) spec() (*Spec, error) {
+
+	spec := &Spec{}
+
+	for _, d := range f.Droplets {
+		region, err := ParseRegion(d.Region)
+		if err != nil {
+			return nil, errors.New("Droplet " + d.Name + ": " + err.Error())
+		}
+		size, err := ParseDropletSize(d.Size)
+		if err != nil {
+			return nil, errors.New("Droplet " + d.Name + ": " + err.Error())
+		}
+		spec.Droplets = append(spec.Droplets, CreateDropletRequest{
+			Name:              d.Name,
+			Region:            region,
+			DropletSize:       size,
+			Image:             d.Image,
+			SSHKeys:           d.SSHKeys,
+			Backups:           d.Backups,
+			IPv6:              d.IPv6,
+			Configuration:     d.UserData,
+			PrivateNetworking: d.PrivateNetworking,
+			Volumes:           d.Volumes,
+			Tags:              d.Tags,
+			VPCUUID:           d.VPCUUID,
+			Monitoring:        d.Monitoring,
+			VPC:               d.VPC,
+			Project:           d.Project,
+		})
+	}
+
+	for _, d := range f.Databases {
+		engine, err := ParseDatabaseType(d.Engine)
+		if err != nil {
+			return nil, errors.New("Database cluster " + d.Name + ": " + err.Error())
+		}
+		size, err := ParseDatabaseSize(d.Size)
+		if err != nil {
+			return nil, errors.New("Database cluster " + d.Name + ": " + err.Error())
+		}
+		region, err := ParseRegion(d.Region)
+		if err != nil {
+			return nil, errors.New("Database cluster " + d.Name + ": " + err.Error())
+		}
+		nodes := d.NumNodes
+		if nodes == 0 {
+			nodes = 1
+		}
+
+		cluster := DatabaseClusterSpec{
+			CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{
+				Name:         d.Name,
+				DatabaseType: engine,
+				Version:      d.Version,
+				DatabaseSize: size,
+				Region:       region,
+				NumNodes:     nodes,
+				Tags:         d.Tags,
+				VPC:          d.VPC,
+				Project:      d.Project,
+			},
+			DBNames: d.DBNames,
+		}
+		if d.MaintenanceWindow != nil {
+			cluster.MaintenanceWindow = &MaintenanceWindow{Day: d.MaintenanceWindow.Day, Time: d.MaintenanceWindow.Hour}
+		}
+		spec.Databases = append(spec.Databases, cluster)
+	}
+
+	return spec, nil
+}
+
+// WriteSpec writes spec to path as JSON when path ends in .json and as YAML
+// otherwise, in the format LoadSpec reads.
+func WriteSpec(path string, spec Spec) error {
+
+	file := newSpecFile(spec)
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		data, err = json.MarshalIndent(file, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(file)
+	}
+	if err != nil {
+		return errors.New("Unable to write spec " + path + ". " + err.Error())
+	}
+
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return errors.New("Unable to write spec " + path + ". " + err.Error())
+	}
+
+	return nil
+}
+
+func newSpecFile(spec Spec) specFile {
+
+	var file specFile
+
+	for _, d := range spec.Droplets {
+		file.Droplets = append(file.Droplets, dropletSpecFile{
+			Name:              d.Name,
+			Region:            d.Region.String(),
+			Size:              d.DropletSize.String(),
+			Image:             d.Image,
+			SSHKeys:           d.SSHKeys,
+			Backups:           d.Backups,
+			IPv6:              d.IPv6,
+			UserData:          d.Configuration,
+			PrivateNetworking: d.PrivateNetworking,
+			Volumes:           d.Volumes,
+			Tags:              d.Tags,
+			VPCUUID:           d.VPCUUID,
+			Monitoring:        d.Monitoring,
+			VPC:               d.VPC,
+			Project:           d.Project,
+		})
+	}
+
+	for _, d := range spec.Databases {
+		cluster := databaseSpecFile{
+			Name:     d.Name,
+			Engine:   d.DatabaseType.String(),
+			Version:  d.Version,
+			Size:     d.DatabaseSize.String(),
+			Region:   d.Region.String(),
+			NumNodes: d.NumNodes,
+			Tags:     d.Tags,
+			VPC:      d.VPC,
+			Project:  d.Project,
+			DBNames:  d.DBNames,
+		}
+		if d.MaintenanceWindow != nil {
+			cluster.MaintenanceWindow = &maintenanceWindow{Day: d.MaintenanceWindow.Day, Hour: d.MaintenanceWindow.Time}
+		}
+		file.Databases = append(file.Databases, cluster)
+	}
+
+	return file
+}
@@ -0,0 +1,241 @@
+package dog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestSpec = Spec{
+	Droplets: []CreateDropletRequest{
+		{Name: "test.example.com", Region: NYC1, DropletSize: S1Cpu1GbRAM, Image: "ubuntu-22-04-x64"},
+		{Name: "web", Region: NYC3, DropletSize: S1Cpu2GbRAM, Image: "ubuntu-22-04-x64", Tags: []string{"web"}},
+	},
+	Databases: []DatabaseClusterSpec{
+		{
+			CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{Name: "dbtest", DatabaseType: MySQL, Version: "8", DatabaseSize: DbS1Cpu1GbRAM10GbStorage, Region: NYC3, NumNodes: 3},
+			MaintenanceWindow:            &MaintenanceWindow{Day: "monday", Time: "13:51"},
+		},
+		{
+			CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{Name: "orders", DatabaseType: PostGres, Version: "16", DatabaseSize: DbS1Cpu1GbRAM10GbStorage, Region: NYC3, NumNodes: 1},
+			MaintenanceWindow:            &MaintenanceWindow{Day: "tuesday", Time: "14:00"},
+		},
+	},
+}
+
+func newTestInfrastructure() Infrastructure {
+	return Infrastructure{
+		droplets:  Droplet{client: &MockGodoDropletSvc{}},
+		databases: Database{client: &MockGodoDatabaseSvc{}},
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	os.WriteFile(path, []byte(`
+droplets:
+  - name: test.example.com
+    region: nyc1
+    size: s-1vcpu-1gb
+    image: ubuntu-22-04-x64
+  - name: web
+    region: nyc3
+    size: s-1vcpu-2gb
+    image: ubuntu-22-04-x64
+    tags: [web]
+databases:
+  - name: dbtest
+    engine: mysql
+    version: "8"
+    size: db-s-1vcpu-1gb
+    region: nyc3
+    num_nodes: 3
+    maintenance_window: {day: monday, hour: "13:51"}
+  - name: orders
+    engine: pg
+    version: "16"
+    size: db-s-1vcpu-1gb
+    region: nyc3
+    maintenance_window: {day: tuesday, hour: "14:00"}
+`), 0600)
+
+	expected := &TestSpec
+	returned, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		os.WriteFile(path, []byte("droplets:\n  - name: web\n    region: mars1\n    size: s-1vcpu-1gb\n"), 0600)
+		expectedError := "Unable to parse spec " + path + ". Droplet web: mars1 is not a region. Use one of nyc1, nyc2, nyc3, ams2, ams3, sfo1, sfo2, sgp1, lon1, fra1, tor1, blr1"
+		_, returnedError := LoadSpec(path)
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+
+		os.WriteFile(path, []byte("droplets:\n  - name: web\n    sise: s-1vcpu-1gb\n"), 0600)
+		_, returnedError = LoadSpec(path)
+		if returnedError == nil {
+			t.Errorf("expected the misspelled field to be rejected")
+		}
+	})
+
+}
+
+func TestPlan(t *testing.T) {
+
+	infra := newTestInfrastructure()
+
+	plan, err := infra.Plan(PlanRequest{Spec: TestSpec, Prune: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "+ droplet web (nyc3, s-1vcpu-2gb, ubuntu-22-04-x64)\n" +
+		"+ database cluster orders (pg 16, nyc3, db-s-1vcpu-1gb x1)\n" +
+		"~ database cluster dbtest: migrate sfo2 -> nyc3\n" +
+		"  database cluster dbtest: resize test size slug x3 -> db-s-1vcpu-1gb x3 waits until the cluster is online, plan again then\n" +
+		"\nPlan: 2 to create, 1 to change, 0 to delete.\n"
+	if returned := plan.String(); expected != returned {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	// the resize is planned once the cluster is in the new region
+	migrated := TestSpec
+	migrated.Databases = append([]DatabaseClusterSpec{}, TestSpec.Databases...)
+	for n := range migrated.Databases {
+		if migrated.Databases[n].Name == "dbtest" {
+			migrated.Databases[n].Region = SFO2
+		}
+	}
+	replanned, _ := infra.Plan(PlanRequest{Spec: migrated})
+	if len(replanned.Deferred) != 0 || replanned.Changes[len(replanned.Changes)-1].Kind != ResizeChange {
+		t.Errorf("expected the resize to be planned without a migration, returned %+v", replanned)
+	}
+
+	// a migrating cluster still reports its old region and takes no changes
+	migrating := ExpectedDB
+	migrating.Status = "migrating"
+	infra.databases.client = &MockGodoDatabaseSvc{listed: []godo.Database{migrating}}
+	replanned, err = infra.Plan(PlanRequest{Spec: TestSpec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedChanges := []Change{
+		{Kind: ResizeChange, Resource: databaseResource, Name: "dbtest", ID: ExpectedDB.ID, From: "test size slug x3", To: "db-s-1vcpu-1gb x3"},
+	}
+	for n := range replanned.Deferred {
+		replanned.Deferred[n].database = nil
+	}
+	if !reflect.DeepEqual(expectedChanges, replanned.Deferred) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expectedChanges, replanned.Deferred)
+	}
+	for _, c := range replanned.Changes {
+		if c.Resource == databaseResource && c.Kind != CreateChange {
+			t.Errorf("expected no changes to the migrating cluster, returned %+v", c)
+		}
+	}
+	infra.databases.client = &MockGodoDatabaseSvc{}
+
+	pruned, _ := infra.Plan(PlanRequest{Prune: true})
+	expectedChanges = []Change{
+		{Kind: DeleteChange, Resource: dropletResource, Name: "test.example.com", ID: "1"},
+		{Kind: DeleteChange, Resource: databaseResource, Name: "dbtest", ID: "TestID-2131241"},
+	}
+	if !reflect.DeepEqual(expectedChanges, pruned.Changes) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expectedChanges, pruned.Changes)
+	}
+
+	unpruned, _ := infra.Plan(PlanRequest{})
+	if unpruned.String() != "No changes.\n" {
+		t.Errorf("expected no changes without prune, returned %s", unpruned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		spec := Spec{Databases: []DatabaseClusterSpec{{CreateDatabaseClusterRequest: CreateDatabaseClusterRequest{Name: "dbtest", DatabaseType: PostGres}}}}
+		expectedError := "Database cluster dbtest is mysql, not pg. Engines cannot be changed in place"
+		_, returnedError := infra.Plan(PlanRequest{Spec: spec})
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+
+		twins := &MockGodoDatabaseSvc{listed: []godo.Database{ExpectedDB, ExpectedDB}}
+		twinInfra := Infrastructure{droplets: infra.droplets, databases: Database{client: twins}}
+		expectedError = "More than one database cluster is named dbtest. Rename all but one to plan it"
+		_, returnedError = twinInfra.Plan(PlanRequest{Spec: TestSpec, Prune: true})
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+
+		spec = Spec{Droplets: []CreateDropletRequest{{Name: "web"}, {Name: "web"}}}
+		expectedError = "Droplet web is in the spec more than once"
+		_, returnedError = infra.Plan(PlanRequest{Spec: spec})
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestApply(t *testing.T) {
+
+	infra := newTestInfrastructure()
+
+	droplets := Spec{Droplets: TestSpec.Droplets[1:]}
+	plan, _ := infra.Plan(PlanRequest{Spec: droplets})
+	if err := infra.Apply(plan); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		plan, _ := infra.Plan(PlanRequest{Spec: TestSpec})
+		expectedError := "Unable to apply change 2 of 3. Unable to configure maintenance window for database cluster." + ExpectedDB.ID + " Godo error: " + TestError
+		returnedError := infra.Apply(plan)
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+
+		pruned, _ := infra.Plan(PlanRequest{Prune: true})
+		expectedError = "Unable to apply change 1 of 2. Unable to delete droplet with ID: 1"
+		returnedError = infra.Apply(pruned)
+		if returnedError == nil || returnedError.Error() != expectedError {
+			t.Errorf("expected: %s\n returned: %v\n", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestWriteSpec(t *testing.T) {
+
+	for _, name := range []string{"spec.yaml", "spec.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := WriteSpec(path, TestSpec); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := &TestSpec
+		returned, err := LoadSpec(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+		}
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "spec.yaml")
+		returnedError := WriteSpec(path, TestSpec)
+		if returnedError == nil {
+			t.Errorf("expected an error writing to a missing directory")
+		}
+	})
+
+}
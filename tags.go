@@ -0,0 +1,253 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateTagRequest struct {
+	Name string
+}
+
+type FindAllTagsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type DeleteTagRequest struct {
+	Name string
+}
+
+type TaggedResource struct {
+	ID string
+	ResourceKind
+}
+
+type TagResourcesRequest struct {
+	Tag       string
+	Resources []TaggedResource
+}
+
+type UntagResourcesRequest struct {
+	Tag       string
+	Resources []TaggedResource
+}
+
+type BulkTagDropletsRequest struct {
+	Tag    string
+	Apply  string
+	DryRun bool
+}
+
+// DeleteDropletsByTagRequest must set Confirm unless DryRun is set, so a
+// zero value request never deletes anything.
+type DeleteDropletsByTagRequest struct {
+	Tag     string
+	Confirm bool
+	DryRun  bool
+}
+
+// Taggable resource kinds
+type ResourceKind int
+
+const (
+	DropletResource ResourceKind = iota
+	VolumeResource
+	DatabaseResource
+	ImageResource
+)
+
+func (rk ResourceKind) String() string {
+	names := [...]string{
+		"droplet",
+		"volume",
+		"database",
+		"image",
+	}
+	if rk < DropletResource || rk > ImageResource {
+		return "That is not a resource kind"
+	}
+	return names[rk]
+}
+
+type TagClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.Tag, *godo.Response, error)
+	Get(context.Context, string) (*godo.Tag, *godo.Response, error)
+	Create(context.Context, *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	TagResources(context.Context, string, *godo.TagResourcesRequest) (*godo.Response, error)
+	UntagResources(context.Context, string, *godo.UntagResourcesRequest) (*godo.Response, error)
+}
+
+type Tag struct {
+	client   TagClient
+	droplets Droplet
+}
+
+func NewTC(pat string) Tag {
+	client := Authenticate(pat)
+	return Tag{client: client.Tags, droplets: Droplet{client: client.Droplets}}
+}
+
+func (t *Tag) GetAllTags(fatr FindAllTagsRequest) ([]godo.Tag, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fatr.Page,
+		PerPage: fatr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	tags, _, err := t.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all tags. Godo error: " + err.Error())
+	}
+
+	return tags, nil
+}
+
+func (t *Tag) GetTag(name string) (*godo.Tag, error) {
+
+	ctx := context.TODO()
+
+	tag, _, err := t.client.Get(ctx, name)
+	if err != nil {
+		return nil, errors.New("Tag: " + name + ", was not found. Godo error: " + err.Error())
+	}
+
+	return tag, nil
+}
+
+func (t *Tag) CreateTag(ctr CreateTagRequest) (*godo.Tag, error) {
+
+	ctx := context.TODO()
+
+	tag, _, err := t.client.Create(ctx, &godo.TagCreateRequest{Name: ctr.Name})
+	if err != nil {
+		return nil, errors.New("Unable to create tag. Godo error: " + err.Error())
+	}
+
+	return tag, nil
+}
+
+func (t *Tag) DeleteTag(dtr DeleteTagRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := t.client.Delete(ctx, dtr.Name)
+	if err != nil {
+		return errors.New("Unable to delete tag: " + dtr.Name + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (t *Tag) TagResources(trr TagResourcesRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := t.client.TagResources(ctx, trr.Tag, &godo.TagResourcesRequest{Resources: godoResources(trr.Resources)})
+	if err != nil {
+		return errors.New("Unable to tag resources with tag: " + trr.Tag + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (t *Tag) UntagResources(urr UntagResourcesRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := t.client.UntagResources(ctx, urr.Tag, &godo.UntagResourcesRequest{Resources: godoResources(urr.Resources)})
+	if err != nil {
+		return errors.New("Unable to untag resources with tag: " + urr.Tag + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+// BulkTagDroplets applies the Apply tag to every droplet carrying Tag and
+// returns the droplets it matched.
+func (t *Tag) BulkTagDroplets(btdr BulkTagDropletsRequest) ([]godo.Droplet, error) {
+
+	if btdr.Apply == "" {
+		return nil, errors.New("Refusing to bulk tag droplets with tag: " + btdr.Tag + " without a tag to Apply")
+	}
+
+	droplets, err := t.allDropletsByTag(btdr.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if btdr.DryRun || len(droplets) == 0 {
+		return droplets, nil
+	}
+
+	resources := make([]TaggedResource, len(droplets))
+	for i, droplet := range droplets {
+		resources[i] = TaggedResource{ID: strconv.Itoa(droplet.ID), ResourceKind: DropletResource}
+	}
+
+	err = t.TagResources(TagResourcesRequest{Tag: btdr.Apply, Resources: resources})
+	if err != nil {
+		return nil, err
+	}
+
+	return droplets, nil
+}
+
+// DeleteDropletsByTag deletes every droplet carrying Tag and returns the
+// droplets it deleted, or with DryRun the droplets it would delete.
+func (t *Tag) DeleteDropletsByTag(ddtr DeleteDropletsByTagRequest) ([]godo.Droplet, error) {
+
+	if !ddtr.Confirm && !ddtr.DryRun {
+		return nil, errors.New("Refusing to delete droplets with tag: " + ddtr.Tag + " without Confirm")
+	}
+
+	droplets, err := t.allDropletsByTag(ddtr.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if ddtr.DryRun {
+		return droplets, nil
+	}
+
+	for i, droplet := range droplets {
+		err := t.droplets.DeleteDroplet(DeleteDropletRequest{ID: droplet.ID})
+		if err != nil {
+			return droplets[:i], err
+		}
+	}
+
+	return droplets, nil
+}
+
+func (t *Tag) allDropletsByTag(tag string) ([]godo.Droplet, error) {
+
+	ctx := context.TODO()
+
+	var all []godo.Droplet
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := t.droplets.client.ListByTag(ctx, tag, opt)
+		if err != nil {
+			return nil, errors.New("Unable to get droplets with tag: " + tag + ". Godo error: " + err.Error())
+		}
+		all = append(all, droplets...)
+
+		if isLastPage(resp) {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+func godoResources(resources []TaggedResource) []godo.Resource {
+	var godoResources []godo.Resource
+
+	for _, resource := range resources {
+		godoResources = append(godoResources, godo.Resource{ID: resource.ID, Type: godo.ResourceType(resource.ResourceKind.String())})
+	}
+	return godoResources
+}
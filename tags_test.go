@@ -0,0 +1,164 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestTag = godo.Tag{
+	Name: "tag",
+}
+
+var TestTags = []godo.Tag{TestTag}
+
+var TestTagResourcesRequest = TagResourcesRequest{
+	Tag: "tag",
+	Resources: []TaggedResource{
+		{ID: "1", ResourceKind: DropletResource},
+		{ID: "TestID-2131241", ResourceKind: DatabaseResource},
+	},
+}
+
+func newTestTag() Tag {
+	tClient := NewTC(TestPAT)
+	tClient.client = &MockGodoTagSvc{}
+	tClient.droplets.client = &MockGodoDropletSvc{}
+	return tClient
+}
+
+func TestGetAllTags(t *testing.T) {
+
+	tClient := newTestTag()
+
+	expected := TestTags
+	returned, _ := tClient.GetAllTags(FindAllTagsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateTag(t *testing.T) {
+
+	tClient := newTestTag()
+
+	expected := &TestTag
+	returned, _ := tClient.CreateTag(CreateTagRequest{Name: "tag"})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestTagResources(t *testing.T) {
+
+	tClient := newTestTag()
+
+	err := tClient.TagResources(TestTagResourcesRequest)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+}
+
+func TestUntagResources(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		tClient := newTestTag()
+
+		expectedError := "Unable to untag resources with tag: tag. Godo error: " + TestError
+		returnedError := tClient.UntagResources(UntagResourcesRequest(TestTagResourcesRequest))
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestBulkTagDroplets(t *testing.T) {
+
+	tClient := newTestTag()
+
+	expected := TestDroplets
+	returned, _ := tClient.BulkTagDroplets(BulkTagDropletsRequest{Tag: "tag", Apply: "web"})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown without a tag to apply", func(t *testing.T) {
+		expectedError := "Refusing to bulk tag droplets with tag: tag without a tag to Apply"
+		_, returnedError := tClient.BulkTagDroplets(BulkTagDropletsRequest{Tag: "tag"})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestDeleteDropletsByTag(t *testing.T) {
+
+	t.Run("Dry run lists droplets", func(t *testing.T) {
+		tClient := newTestTag()
+
+		expected := TestDroplets
+		returned, _ := tClient.DeleteDropletsByTag(DeleteDropletsByTagRequest{Tag: "tag", DryRun: true})
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+		}
+	})
+
+	t.Run("Error is thrown without confirmation", func(t *testing.T) {
+		tClient := newTestTag()
+
+		expectedError := "Refusing to delete droplets with tag: tag without Confirm"
+		_, returnedError := tClient.DeleteDropletsByTag(DeleteDropletsByTagRequest{Tag: "tag"})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+	t.Run("Error is thrown when delete fails", func(t *testing.T) {
+		tClient := newTestTag()
+
+		expectedError := "Unable to delete droplet with ID: " + strconv.Itoa(TestDroplet.ID)
+		returned, returnedError := tClient.DeleteDropletsByTag(DeleteDropletsByTagRequest{Tag: "tag", Confirm: true})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+		if len(returned) != 0 {
+			t.Errorf("expected no deleted droplets, returned %+v\n", returned)
+		}
+	})
+
+}
+
+type MockGodoTagSvc struct{}
+
+func (m *MockGodoTagSvc) List(context.Context, *godo.ListOptions) ([]godo.Tag, *godo.Response, error) {
+	return TestTags, nil, nil
+}
+
+func (m *MockGodoTagSvc) Get(context.Context, string) (*godo.Tag, *godo.Response, error) {
+	return &TestTag, nil, nil
+}
+
+func (m *MockGodoTagSvc) Create(context.Context, *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+	return &TestTag, nil, nil
+}
+
+func (m *MockGodoTagSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoTagSvc) TagResources(context.Context, string, *godo.TagResourcesRequest) (*godo.Response, error) {
+	return nil, nil
+}
+
+func (m *MockGodoTagSvc) UntagResources(context.Context, string, *godo.UntagResourcesRequest) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
@@ -0,0 +1,198 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/evancaplan/dog"
+
+// WithTelemetry traces each droplet and database operation with a span from
+// tp and records request count, latency, errors by kind and the remaining
+// rate limit with mp. A nil provider means the global one.
+func WithTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) Option {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	t := newTelemetry(tp, mp)
+	return func(o *clientOptions) {
+		o.telemetry = t
+	}
+}
+
+type telemetry struct {
+	tracer    trace.Tracer
+	requests  metric.Int64Counter
+	duration  metric.Float64Histogram
+	errors    metric.Int64Counter
+	rateLimit metric.Int64Gauge
+}
+
+// newTelemetry creates the instruments, passing failures to the OTel error
+// handler as instrumentation libraries do, which leaves no-op instruments.
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *telemetry {
+
+	meter := mp.Meter(instrumentationName)
+	t := &telemetry{tracer: tp.Tracer(instrumentationName)}
+
+	var err error
+	t.requests, err = meter.Int64Counter("dog.client.requests",
+		metric.WithDescription("API requests made"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	t.duration, err = meter.Float64Histogram("dog.client.request.duration",
+		metric.WithDescription("Duration of API requests"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	t.errors, err = meter.Int64Counter("dog.client.errors",
+		metric.WithDescription("API requests that failed, by kind of error"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	t.rateLimit, err = meter.Int64Gauge("dog.client.rate_limit.remaining",
+		metric.WithDescription("Requests left in the current rate limit window"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return t
+}
+
+// observer logs and traces API calls. The zero value does neither.
+type observer struct {
+	logger    *slog.Logger
+	telemetry *telemetry
+}
+
+func (o *clientOptions) observer() observer {
+	return observer{logger: o.logger, telemetry: o.telemetry}
+}
+
+// operation is a single API call being observed.
+type operation struct {
+	observer
+	name  string
+	start time.Time
+	span  trace.Span
+	attrs []slog.Attr
+}
+
+// begin starts observing the call name, returning the context to make it
+// with. attrs describe the call, e.g. the ID of the resource it is about.
+func (o observer) begin(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, *operation) {
+	op := &operation{observer: o, name: name, start: time.Now(), attrs: attrs}
+	if o.telemetry != nil {
+		ctx, op.span = o.telemetry.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	}
+	return ctx, op
+}
+
+// add describes the call further once it has been made, e.g. with the ID of
+// the resource it created.
+func (op *operation) add(attrs ...slog.Attr) {
+	op.attrs = append(op.attrs, attrs...)
+}
+
+// end finishes observing the call, given its response and error.
+func (op *operation) end(resp *godo.Response, err error) {
+
+	logOperation(op.logger, op.name, op.start, resp, err, op.attrs...)
+
+	t := op.telemetry
+	if t == nil {
+		return
+	}
+	ctx := context.TODO()
+	elapsed := time.Since(op.start)
+
+	name := attribute.String("dog.operation", op.name)
+	requestAttrs := []attribute.KeyValue{name}
+	if resp != nil && resp.Response != nil {
+		status := attribute.Int("http.response.status_code", resp.StatusCode)
+		requestAttrs = append(requestAttrs, status)
+		op.span.SetAttributes(status)
+		t.rateLimit.Record(ctx, int64(resp.Rate.Remaining))
+	}
+
+	op.span.SetAttributes(spanAttributes(op.attrs)...)
+	t.requests.Add(ctx, 1, metric.WithAttributes(requestAttrs...))
+	t.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(name))
+
+	if err != nil {
+		kind := attribute.String("error.type", errorKind(resp, err))
+		op.span.SetAttributes(kind)
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+		t.errors.Add(ctx, 1, metric.WithAttributes(name, kind))
+	}
+	op.span.End()
+}
+
+// spanAttributes converts the string and number attributes of a call. The
+// logged request is left out so that spans stay small.
+func spanAttributes(attrs []slog.Attr) []attribute.KeyValue {
+	var kvs []attribute.KeyValue
+	for _, a := range attrs {
+		switch a.Value.Kind() {
+		case slog.KindString:
+			kvs = append(kvs, attribute.String("dog."+a.Key, a.Value.String()))
+		case slog.KindInt64:
+			kvs = append(kvs, attribute.Int64("dog."+a.Key, a.Value.Int64()))
+		}
+	}
+	return kvs
+}
+
+// errorKind sorts a failed call into a small set of kinds, by the status the
+// API answered with when it answered.
+func errorKind(resp *godo.Response, err error) string {
+
+	var errorResponse *godo.ErrorResponse
+	var argError *godo.ArgError
+	status := 0
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &argError):
+		return "invalid_argument"
+	case errors.As(err, &errorResponse) && errorResponse.Response != nil:
+		status = errorResponse.Response.StatusCode
+	case resp != nil && resp.Response != nil:
+		status = resp.StatusCode
+	default:
+		return "transport"
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return "unauthorized"
+	case status == http.StatusNotFound:
+		return "not_found"
+	case status == http.StatusTooManyRequests:
+		return "rate_limited"
+	case status >= http.StatusInternalServerError:
+		return "server"
+	case status >= http.StatusBadRequest:
+		return "client"
+	}
+	return "unknown"
+}
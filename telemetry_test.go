@@ -0,0 +1,201 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestTelemetry returns an option recording to in-memory exporters.
+func newTestTelemetry() (Option, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	return WithTelemetry(tp, mp), spans, reader
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestTelemetry(t *testing.T) {
+
+	option, spans, reader := newTestTelemetry()
+	dbClient := NewDBCWithClient(&MockGodoDatabaseSvc{}, option)
+
+	if _, err := dbClient.Create(TestCreateDatabaseClusterRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 || stubs[0].Name != "Databases.Create" {
+		t.Fatalf("expected a Databases.Create span, returned %+v", stubs)
+	}
+	expected := map[attribute.Key]string{"dog.region": "fra1", "dog.size": "db-s-1vcpu-1gb", "dog.cluster_id": ExpectedDB.ID}
+	for key, value := range expected {
+		if returned := spanAttribute(stubs[0], key).AsString(); returned != value {
+			t.Errorf("expected %s to be %+v\n , returned, %+v\n ", key, value, returned)
+		}
+	}
+	if spanAttribute(stubs[0], "dog.request").Type() != attribute.INVALID {
+		t.Errorf("expected the request to be left out of the span")
+	}
+
+	metrics := collect(t, reader)
+	requests := metrics["dog.client.requests"].(metricdata.Sum[int64])
+	if len(requests.DataPoints) != 1 || requests.DataPoints[0].Value != 1 {
+		t.Errorf("expected a request to be counted, returned %+v", requests)
+	}
+	duration := metrics["dog.client.request.duration"].(metricdata.Histogram[float64])
+	if len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 1 {
+		t.Errorf("expected a duration to be recorded, returned %+v", duration)
+	}
+
+	// Get and Create describe the resource by its region and size
+	spans.Reset()
+	if _, err := dbClient.GetById(ExpectedDB.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dClient := NewDCWithClient(&MockGodoDropletSvc{}, option)
+	if _, err := dClient.GetDropletById(FindDropletByIDRequest{ID: TestDroplet.ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := dClient.CreateDroplet(TestCreateDropletRequest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(spans.GetSpans()) != 3 {
+		t.Fatalf("expected a span per call, returned %+v", spans.GetSpans())
+	}
+	for _, span := range spans.GetSpans() {
+		if spanAttribute(span, "dog.region").Type() != attribute.STRING || spanAttribute(span, "dog.size").AsString() == "" {
+			t.Errorf("expected %s to have a region and size, returned %+v", span.Name, span.Attributes)
+		}
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("RateLimit-Limit", "5000")
+			w.Header().Set("RateLimit-Remaining", "4321")
+			w.Header().Set("x-request-id", "b7a1c9d2")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"id":"not_found","message":"The resource you requested could not be found."}`))
+		}))
+		defer server.Close()
+
+		u, _ := url.Parse(server.URL)
+		option, spans, reader := newTestTelemetry()
+		dClient := NewDC(TestPAT, WithBaseURL(u), option)
+
+		if _, err := dClient.GetDropletById(FindDropletByIDRequest{ID: 7}); err == nil {
+			t.Fatalf("expected the missing droplet to be an error")
+		}
+
+		span := spans.GetSpans()[0]
+		if span.Status.Code != codes.Error || spanAttribute(span, "http.response.status_code").AsInt64() != 404 || spanAttribute(span, "dog.droplet_id").AsInt64() != 7 {
+			t.Errorf("unexpected span: %+v", span)
+		}
+
+		metrics := collect(t, reader)
+		errorCount := metrics["dog.client.errors"].(metricdata.Sum[int64])
+		kind, _ := errorCount.DataPoints[0].Attributes.Value("error.type")
+		if len(errorCount.DataPoints) != 1 || kind.AsString() != "not_found" {
+			t.Errorf("expected a not_found error, returned %+v", errorCount)
+		}
+		rateLimit := metrics["dog.client.rate_limit.remaining"].(metricdata.Gauge[int64])
+		if len(rateLimit.DataPoints) != 1 || rateLimit.DataPoints[0].Value != 4321 {
+			t.Errorf("expected 4321 requests remaining, returned %+v", rateLimit)
+		}
+	})
+
+}
+
+func TestPlanTelemetry(t *testing.T) {
+
+	option, spans, reader := newTestTelemetry()
+	infra := Infrastructure{
+		droplets:  NewDCWithClient(&MockGodoDropletSvc{}, option),
+		databases: NewDBCWithClient(&MockGodoDatabaseSvc{}, option),
+	}
+
+	if _, err := infra.Plan(PlanRequest{Spec: TestSpec}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, span := range spans.GetSpans() {
+		names = append(names, span.Name)
+		if spanAttribute(span, "dog.page").AsInt64() != 1 {
+			t.Errorf("expected the first page to be listed, returned %+v", span)
+		}
+	}
+	expected := []string{"Droplets.List", "Databases.List"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, names)
+	}
+
+	requests := collect(t, reader)["dog.client.requests"].(metricdata.Sum[int64])
+	if len(requests.DataPoints) != 2 {
+		t.Errorf("expected a request to be counted for each listing, returned %+v", requests)
+	}
+
+}
+
+func TestErrorKind(t *testing.T) {
+
+	response := func(status int) *godo.Response {
+		return &godo.Response{Response: &http.Response{StatusCode: status}}
+	}
+
+	tests := []struct {
+		resp     *godo.Response
+		err      error
+		expected string
+	}{
+		{response(401), errors.New(TestError), "unauthorized"},
+		{response(422), errors.New(TestError), "client"},
+		{response(429), errors.New(TestError), "rate_limited"},
+		{response(503), errors.New(TestError), "server"},
+		{nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: 404}}, "not_found"},
+		{nil, context.DeadlineExceeded, "timeout"},
+		{nil, godo.NewArgError("name", "cannot be empty"), "invalid_argument"},
+		{nil, errors.New(TestError), "transport"},
+	}
+	for _, test := range tests {
+		if returned := errorKind(test.resp, test.err); returned != test.expected {
+			t.Errorf("expected %+v\n , returned, %+v\n ", test.expected, returned)
+		}
+	}
+
+}
@@ -0,0 +1,427 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// CreateUptimeCheckRequest checks Target from every region when Regions is empty.
+type CreateUptimeCheckRequest struct {
+	Name string
+	UptimeCheckKind
+	Target   string
+	Regions  []CheckRegion
+	Disabled bool
+}
+
+type FindAllUptimeChecksRequest struct {
+	Page    int
+	PerPage int
+}
+
+// UpdateUptimeCheckRequest replaces every field of the check with ID.
+type UpdateUptimeCheckRequest struct {
+	ID   string
+	Name string
+	UptimeCheckKind
+	Target   string
+	Regions  []CheckRegion
+	Disabled bool
+}
+
+type DeleteUptimeCheckRequest struct {
+	ID string
+}
+
+// UptimeAlertRequest notifies Emails and Slack when the check crosses
+// Threshold for AlertWindow. Threshold is in milliseconds for latency alerts,
+// regions for down alerts and days for SSL expiry alerts.
+type UptimeAlertRequest struct {
+	CheckID string
+	Name    string
+	UptimeAlertKind
+	Threshold int
+	AlertComparison
+	AlertWindow
+	Emails []string
+	Slack  []godo.SlackDetails
+}
+
+type UpdateUptimeAlertRequest struct {
+	ID string
+	UptimeAlertRequest
+}
+
+type FindAllUptimeAlertsRequest struct {
+	CheckID string
+	Page    int
+	PerPage int
+}
+
+type DeleteUptimeAlertRequest struct {
+	CheckID string
+	ID      string
+}
+
+type RegionUptime struct {
+	Region          string
+	Status          string
+	Since           time.Time
+	ThirtyDayUptime float32
+}
+
+type Outage struct {
+	Region   string
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+}
+
+// UptimeHistory is what the API keeps of a check's past: the 30 day uptime
+// of each region and the most recent outage, if there was one.
+type UptimeHistory struct {
+	Regions        []RegionUptime
+	PreviousOutage *Outage
+}
+
+// Uptime check kinds
+type UptimeCheckKind int
+
+const (
+	HTTPCheck UptimeCheckKind = iota
+	HTTPSCheck
+	PingCheck
+)
+
+func (uck UptimeCheckKind) String() string {
+	names := [...]string{
+		"http",
+		"https",
+		"ping",
+	}
+	if uck < HTTPCheck || uck > PingCheck {
+		return "That is not an uptime check kind"
+	}
+	return names[uck]
+}
+
+// Uptime check regions
+type CheckRegion int
+
+const (
+	USEast CheckRegion = iota
+	USWest
+	EUWest
+	SEAsia
+)
+
+func (cr CheckRegion) String() string {
+	names := [...]string{
+		"us_east",
+		"us_west",
+		"eu_west",
+		"se_asia",
+	}
+	if cr < USEast || cr > SEAsia {
+		return "That is not a check region"
+	}
+	return names[cr]
+}
+
+// Uptime alert kinds
+type UptimeAlertKind int
+
+const (
+	LatencyAlert UptimeAlertKind = iota
+	DownAlert
+	DownGlobalAlert
+	SSLExpiryAlert
+)
+
+func (uak UptimeAlertKind) String() string {
+	names := [...]string{
+		"latency",
+		"down",
+		"down_global",
+		"ssl_expiry",
+	}
+	if uak < LatencyAlert || uak > SSLExpiryAlert {
+		return "That is not an uptime alert kind"
+	}
+	return names[uak]
+}
+
+type UptimeClient interface {
+	List(context.Context, *godo.ListOptions) ([]godo.UptimeCheck, *godo.Response, error)
+	Get(context.Context, string) (*godo.UptimeCheck, *godo.Response, error)
+	GetState(context.Context, string) (*godo.UptimeCheckState, *godo.Response, error)
+	Create(context.Context, *godo.CreateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error)
+	Update(context.Context, string, *godo.UpdateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+	GetAlert(context.Context, string, string) (*godo.UptimeAlert, *godo.Response, error)
+	ListAlerts(context.Context, string, *godo.ListOptions) ([]godo.UptimeAlert, *godo.Response, error)
+	CreateAlert(context.Context, string, *godo.CreateUptimeAlertRequest) (*godo.UptimeAlert, *godo.Response, error)
+	UpdateAlert(context.Context, string, string, *godo.UpdateUptimeAlertRequest) (*godo.UptimeAlert, *godo.Response, error)
+	DeleteAlert(context.Context, string, string) (*godo.Response, error)
+}
+
+type Uptime struct {
+	client UptimeClient
+}
+
+func NewUC(pat string) Uptime {
+	client := Authenticate(pat)
+	return Uptime{client: client.UptimeChecks}
+}
+
+// DropletTarget is the target for checking droplet over its public IPv4
+// address with kind.
+func DropletTarget(droplet *godo.Droplet, kind UptimeCheckKind) (string, error) {
+	ip, err := droplet.PublicIPv4()
+	if err != nil || ip == "" {
+		return "", errors.New("Droplet with name: " + droplet.Name + " has no public IPv4 address")
+	}
+	if kind == PingCheck {
+		return ip, nil
+	}
+	return kind.String() + "://" + ip, nil
+}
+
+func (u *Uptime) GetAllChecks(faucr FindAllUptimeChecksRequest) ([]godo.UptimeCheck, error) {
+
+	opt := &godo.ListOptions{
+		Page:    faucr.Page,
+		PerPage: faucr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	checks, _, err := u.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all uptime checks. Godo error: " + err.Error())
+	}
+
+	return checks, nil
+}
+
+func (u *Uptime) GetCheck(id string) (*godo.UptimeCheck, error) {
+
+	ctx := context.TODO()
+
+	check, _, err := u.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("Uptime check with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return check, nil
+}
+
+func (u *Uptime) CreateCheck(cucr CreateUptimeCheckRequest) (*godo.UptimeCheck, error) {
+
+	create := &godo.CreateUptimeCheckRequest{
+		Name:    cucr.Name,
+		Type:    cucr.UptimeCheckKind.String(),
+		Target:  cucr.Target,
+		Regions: checkRegions(cucr.Regions),
+		Enabled: !cucr.Disabled,
+	}
+
+	ctx := context.TODO()
+
+	check, _, err := u.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create uptime check. Godo error: " + err.Error())
+	}
+
+	return check, nil
+}
+
+func (u *Uptime) UpdateCheck(uucr UpdateUptimeCheckRequest) (*godo.UptimeCheck, error) {
+
+	update := &godo.UpdateUptimeCheckRequest{
+		Name:    uucr.Name,
+		Type:    uucr.UptimeCheckKind.String(),
+		Target:  uucr.Target,
+		Regions: checkRegions(uucr.Regions),
+		Enabled: !uucr.Disabled,
+	}
+
+	ctx := context.TODO()
+
+	check, _, err := u.client.Update(ctx, uucr.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update uptime check with id: " + uucr.ID + ". Godo error: " + err.Error())
+	}
+
+	return check, nil
+}
+
+func (u *Uptime) DeleteCheck(ducr DeleteUptimeCheckRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := u.client.Delete(ctx, ducr.ID)
+	if err != nil {
+		return errors.New("Unable to delete uptime check with id: " + ducr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (u *Uptime) GetCheckState(id string) (*godo.UptimeCheckState, error) {
+
+	ctx := context.TODO()
+
+	state, _, err := u.client.GetState(ctx, id)
+	if err != nil {
+		return nil, errors.New("Unable to get state of uptime check with id: " + id + ". Godo error: " + err.Error())
+	}
+
+	return state, nil
+}
+
+// GetCheckHistory reads the check state into a history sorted by region.
+func (u *Uptime) GetCheckHistory(id string) (*UptimeHistory, error) {
+
+	state, err := u.GetCheckState(id)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &UptimeHistory{}
+	for name, region := range state.Regions {
+		history.Regions = append(history.Regions, RegionUptime{
+			Region:          name,
+			Status:          region.Status,
+			Since:           parseUptimeTime(region.StatusChangedAt),
+			ThirtyDayUptime: region.ThirtyDayUptimePercentage,
+		})
+	}
+	sort.Slice(history.Regions, func(i, j int) bool {
+		return history.Regions[i].Region < history.Regions[j].Region
+	})
+
+	outage := state.PreviousOutage
+	if outage.StartedAt != "" {
+		history.PreviousOutage = &Outage{
+			Region:   outage.Region,
+			Start:    parseUptimeTime(outage.StartedAt),
+			End:      parseUptimeTime(outage.EndedAt),
+			Duration: time.Duration(outage.DurationSeconds) * time.Second,
+		}
+	}
+
+	return history, nil
+}
+
+func (u *Uptime) GetAllAlerts(fauar FindAllUptimeAlertsRequest) ([]godo.UptimeAlert, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fauar.Page,
+		PerPage: fauar.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	alerts, _, err := u.client.ListAlerts(ctx, fauar.CheckID, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get alerts of uptime check with id: " + fauar.CheckID + ". Godo error: " + err.Error())
+	}
+
+	return alerts, nil
+}
+
+func (u *Uptime) GetAlert(checkID string, id string) (*godo.UptimeAlert, error) {
+
+	ctx := context.TODO()
+
+	alert, _, err := u.client.GetAlert(ctx, checkID, id)
+	if err != nil {
+		return nil, errors.New("Uptime alert with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return alert, nil
+}
+
+func (u *Uptime) CreateAlert(uar UptimeAlertRequest) (*godo.UptimeAlert, error) {
+
+	create := &godo.CreateUptimeAlertRequest{
+		Name:          uar.Name,
+		Type:          uar.UptimeAlertKind.String(),
+		Threshold:     uar.Threshold,
+		Comparison:    uptimeComparison(uar.AlertComparison),
+		Notifications: &godo.Notifications{Email: uar.Emails, Slack: uar.Slack},
+		Period:        uar.AlertWindow.String(),
+	}
+
+	ctx := context.TODO()
+
+	alert, _, err := u.client.CreateAlert(ctx, uar.CheckID, create)
+	if err != nil {
+		return nil, errors.New("Unable to create alert for uptime check with id: " + uar.CheckID + ". Godo error: " + err.Error())
+	}
+
+	return alert, nil
+}
+
+func (u *Uptime) UpdateAlert(uuar UpdateUptimeAlertRequest) (*godo.UptimeAlert, error) {
+
+	update := &godo.UpdateUptimeAlertRequest{
+		Name:          uuar.Name,
+		Type:          uuar.UptimeAlertKind.String(),
+		Threshold:     uuar.Threshold,
+		Comparison:    uptimeComparison(uuar.AlertComparison),
+		Notifications: &godo.Notifications{Email: uuar.Emails, Slack: uuar.Slack},
+		Period:        uuar.AlertWindow.String(),
+	}
+
+	ctx := context.TODO()
+
+	alert, _, err := u.client.UpdateAlert(ctx, uuar.CheckID, uuar.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update uptime alert with id: " + uuar.ID + ". Godo error: " + err.Error())
+	}
+
+	return alert, nil
+}
+
+func (u *Uptime) DeleteAlert(duar DeleteUptimeAlertRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := u.client.DeleteAlert(ctx, duar.CheckID, duar.ID)
+	if err != nil {
+		return errors.New("Unable to delete uptime alert with id: " + duar.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func checkRegions(regions []CheckRegion) []string {
+	if len(regions) == 0 {
+		regions = []CheckRegion{USEast, USWest, EUWest, SEAsia}
+	}
+	names := make([]string, len(regions))
+	for i, region := range regions {
+		names[i] = region.String()
+	}
+	return names
+}
+
+func uptimeComparison(ac AlertComparison) godo.UptimeAlertComp {
+	if ac == Below {
+		return godo.UptimeAlertLessThan
+	}
+	return godo.UptimeAlertGreaterThan
+}
+
+// parseUptimeTime returns the zero time for timestamps the API leaves empty.
+func parseUptimeTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
@@ -0,0 +1,189 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestUptimeCheck = godo.UptimeCheck{
+	ID:      "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+	Name:    "web",
+	Type:    "https",
+	Target:  "https://10.0.0.2",
+	Regions: []string{"us_east", "us_west", "eu_west", "se_asia"},
+	Enabled: true,
+}
+
+var TestUptimeAlert = godo.UptimeAlert{
+	ID:         "17f0f0ae-b7e5-4ef6-86e3-aa569db58284",
+	Name:       "web is slow",
+	Type:       "latency",
+	Threshold:  300,
+	Comparison: godo.UptimeAlertGreaterThan,
+	Notifications: &godo.Notifications{
+		Email: []string{"ops@example.com"},
+	},
+	Period: "5m",
+}
+
+var TestUptimeCheckState = godo.UptimeCheckState{
+	Regions: map[string]godo.UptimeRegion{
+		"us_west": {Status: "UP", StatusChangedAt: "2022-03-17T22:28:51Z", ThirtyDayUptimePercentage: 99.5},
+		"eu_west": {Status: "DOWN", StatusChangedAt: "2022-03-18T10:00:00Z", ThirtyDayUptimePercentage: 97.1},
+	},
+	PreviousOutage: godo.UptimePreviousOutage{
+		Region:          "eu_west",
+		StartedAt:       "2022-03-03T06:07:00Z",
+		EndedAt:         "2022-03-03T06:08:00Z",
+		DurationSeconds: 60,
+	},
+}
+
+func TestDropletTarget(t *testing.T) {
+
+	expected := "https://10.0.0.2"
+	returned, _ := DropletTarget(&TestNetworkedDroplet, HTTPSCheck)
+	if expected != returned {
+		t.Errorf("expected %s\n , returned, %s\n ", expected, returned)
+	}
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "Droplet with name: " + TestDroplet.Name + " has no public IPv4 address"
+		_, returnedError := DropletTarget(&TestDroplet, PingCheck)
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestCreateCheck(t *testing.T) {
+
+	mock := &MockGodoUptimeSvc{}
+	uClient := NewUC(TestPAT)
+	uClient.client = mock
+
+	expected := &TestUptimeCheck
+	returned, _ := uClient.CreateCheck(CreateUptimeCheckRequest{Name: "web", UptimeCheckKind: HTTPSCheck, Target: "https://10.0.0.2"})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+	if !reflect.DeepEqual(TestUptimeCheck.Regions, mock.created.Regions) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", TestUptimeCheck.Regions, mock.created.Regions)
+	}
+
+}
+
+func TestDeleteCheck(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		uClient := NewUC(TestPAT)
+		uClient.client = &MockGodoUptimeSvc{}
+
+		expectedError := "Unable to delete uptime check with id: " + TestUptimeCheck.ID + ". Godo error: " + TestError
+		returnedError := uClient.DeleteCheck(DeleteUptimeCheckRequest{ID: TestUptimeCheck.ID})
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestGetCheckHistory(t *testing.T) {
+
+	uClient := NewUC(TestPAT)
+	uClient.client = &MockGodoUptimeSvc{}
+
+	expected := &UptimeHistory{
+		Regions: []RegionUptime{
+			{Region: "eu_west", Status: "DOWN", Since: time.Date(2022, 3, 18, 10, 0, 0, 0, time.UTC), ThirtyDayUptime: 97.1},
+			{Region: "us_west", Status: "UP", Since: time.Date(2022, 3, 17, 22, 28, 51, 0, time.UTC), ThirtyDayUptime: 99.5},
+		},
+		PreviousOutage: &Outage{
+			Region:   "eu_west",
+			Start:    time.Date(2022, 3, 3, 6, 7, 0, 0, time.UTC),
+			End:      time.Date(2022, 3, 3, 6, 8, 0, 0, time.UTC),
+			Duration: time.Minute,
+		},
+	}
+	returned, _ := uClient.GetCheckHistory(TestUptimeCheck.ID)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateAlert(t *testing.T) {
+
+	uClient := NewUC(TestPAT)
+	uClient.client = &MockGodoUptimeSvc{}
+
+	expected := &TestUptimeAlert
+	returned, _ := uClient.CreateAlert(UptimeAlertRequest{
+		CheckID:         TestUptimeCheck.ID,
+		Name:            "web is slow",
+		UptimeAlertKind: LatencyAlert,
+		Threshold:       300,
+		AlertComparison: Above,
+		AlertWindow:     FiveMinutes,
+		Emails:          []string{"ops@example.com"},
+	})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+type MockGodoUptimeSvc struct {
+	created *godo.CreateUptimeCheckRequest
+}
+
+func (m *MockGodoUptimeSvc) List(context.Context, *godo.ListOptions) ([]godo.UptimeCheck, *godo.Response, error) {
+	return []godo.UptimeCheck{TestUptimeCheck}, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) Get(context.Context, string) (*godo.UptimeCheck, *godo.Response, error) {
+	return &TestUptimeCheck, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) GetState(context.Context, string) (*godo.UptimeCheckState, *godo.Response, error) {
+	return &TestUptimeCheckState, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) Create(_ context.Context, request *godo.CreateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error) {
+	m.created = request
+	return &TestUptimeCheck, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) Update(context.Context, string, *godo.UpdateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error) {
+	return &TestUptimeCheck, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
+
+func (m *MockGodoUptimeSvc) GetAlert(context.Context, string, string) (*godo.UptimeAlert, *godo.Response, error) {
+	return &TestUptimeAlert, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) ListAlerts(context.Context, string, *godo.ListOptions) ([]godo.UptimeAlert, *godo.Response, error) {
+	return []godo.UptimeAlert{TestUptimeAlert}, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) CreateAlert(context.Context, string, *godo.CreateUptimeAlertRequest) (*godo.UptimeAlert, *godo.Response, error) {
+	return &TestUptimeAlert, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) UpdateAlert(context.Context, string, string, *godo.UpdateUptimeAlertRequest) (*godo.UptimeAlert, *godo.Response, error) {
+	return &TestUptimeAlert, nil, nil
+}
+
+func (m *MockGodoUptimeSvc) DeleteAlert(context.Context, string, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}
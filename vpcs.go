@@ -0,0 +1,201 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/digitalocean/godo"
+)
+
+type CreateVPCRequest struct {
+	Name string
+	Region
+	Description string
+	IPRange     string
+}
+
+type FindAllVPCsRequest struct {
+	Page    int
+	PerPage int
+}
+
+type UpdateVPCRequest struct {
+	ID          string
+	Name        string
+	Description string
+	Default     *bool
+}
+
+type DeleteVPCRequest struct {
+	ID string
+}
+
+type FindVPCMembersRequest struct {
+	ID           string
+	ResourceType string
+	Page         int
+	PerPage      int
+}
+
+type VPCClient interface {
+	Create(context.Context, *godo.VPCCreateRequest) (*godo.VPC, *godo.Response, error)
+	Get(context.Context, string) (*godo.VPC, *godo.Response, error)
+	List(context.Context, *godo.ListOptions) ([]*godo.VPC, *godo.Response, error)
+	ListMembers(context.Context, string, *godo.VPCListMembersRequest, *godo.ListOptions) ([]*godo.VPCMember, *godo.Response, error)
+	Update(context.Context, string, *godo.VPCUpdateRequest) (*godo.VPC, *godo.Response, error)
+	Delete(context.Context, string) (*godo.Response, error)
+}
+
+type VPC struct {
+	client VPCClient
+}
+
+func NewVPCC(pat string) VPC {
+	client := Authenticate(pat)
+	return VPC{client: client.VPCs}
+}
+
+func (v *VPC) GetAllVPCs(favr FindAllVPCsRequest) ([]*godo.VPC, error) {
+
+	opt := &godo.ListOptions{
+		Page:    favr.Page,
+		PerPage: favr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	vpcs, _, err := v.client.List(ctx, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get all VPCs. Godo error: " + err.Error())
+	}
+
+	return vpcs, nil
+}
+
+func (v *VPC) GetVPC(id string) (*godo.VPC, error) {
+
+	ctx := context.TODO()
+
+	vpc, _, err := v.client.Get(ctx, id)
+	if err != nil {
+		return nil, errors.New("VPC with id: " + id + ", was not found. Godo error: " + err.Error())
+	}
+
+	return vpc, nil
+}
+
+// FindVPC looks a VPC up by either its name or its UUID.
+func (v *VPC) FindVPC(nameOrID string) (*godo.VPC, error) {
+
+	ctx := context.TODO()
+
+	return findVPC(ctx, observer{}, v.client, nameOrID)
+}
+
+func (v *VPC) CreateVPC(cvr CreateVPCRequest) (*godo.VPC, error) {
+
+	create := &godo.VPCCreateRequest{
+		Name:        cvr.Name,
+		RegionSlug:  cvr.Region.String(),
+		Description: cvr.Description,
+		IPRange:     cvr.IPRange,
+	}
+
+	ctx := context.TODO()
+
+	vpc, _, err := v.client.Create(ctx, create)
+	if err != nil {
+		return nil, errors.New("Unable to create VPC. Godo error: " + err.Error())
+	}
+
+	return vpc, nil
+}
+
+func (v *VPC) UpdateVPC(uvr UpdateVPCRequest) (*godo.VPC, error) {
+
+	update := &godo.VPCUpdateRequest{
+		Name:        uvr.Name,
+		Description: uvr.Description,
+		Default:     uvr.Default,
+	}
+
+	ctx := context.TODO()
+
+	vpc, _, err := v.client.Update(ctx, uvr.ID, update)
+	if err != nil {
+		return nil, errors.New("Unable to update VPC with id: " + uvr.ID + ". Godo error: " + err.Error())
+	}
+
+	return vpc, nil
+}
+
+func (v *VPC) DeleteVPC(dvr DeleteVPCRequest) error {
+
+	ctx := context.TODO()
+
+	_, err := v.client.Delete(ctx, dvr.ID)
+	if err != nil {
+		return errors.New("Unable to delete VPC with id: " + dvr.ID + ". Godo error: " + err.Error())
+	}
+	return nil
+}
+
+func (v *VPC) GetVPCMembers(fvmr FindVPCMembersRequest) ([]*godo.VPCMember, error) {
+
+	opt := &godo.ListOptions{
+		Page:    fvmr.Page,
+		PerPage: fvmr.PerPage,
+	}
+
+	ctx := context.TODO()
+
+	members, _, err := v.client.ListMembers(ctx, fvmr.ID, &godo.VPCListMembersRequest{ResourceType: fvmr.ResourceType}, opt)
+	if err != nil {
+		return nil, errors.New("Unable to get members of VPC with id: " + fvmr.ID + ". Godo error: " + err.Error())
+	}
+
+	return members, nil
+}
+
+func findVPC(ctx context.Context, o observer, client VPCClient, nameOrID string) (*godo.VPC, error) {
+
+	if client == nil {
+		return nil, errors.New("VPC: " + nameOrID + " can not be looked up without a VPC client")
+	}
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		pageCtx, op := o.begin(ctx, "VPCs.List", slog.Int("page", opt.Page))
+		vpcs, resp, err := client.List(pageCtx, opt)
+		op.end(resp, err)
+		if err != nil {
+			return nil, errors.New("Unable to get all VPCs. Godo error: " + err.Error())
+		}
+		for _, vpc := range vpcs {
+			if vpc.ID == nameOrID || vpc.Name == nameOrID {
+				return vpc, nil
+			}
+		}
+		if isLastPage(resp) {
+			return nil, errors.New("VPC: " + nameOrID + ", was not found")
+		}
+		opt.Page++
+	}
+}
+
+// resolveVPC finds a VPC by name or UUID and checks it lives in region, since
+// droplets and database clusters can only join a VPC in their own region.
+func resolveVPC(ctx context.Context, o observer, client VPCClient, nameOrID string, region Region) (*godo.VPC, error) {
+
+	vpc, err := findVPC(ctx, o, client, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if vpc.RegionSlug != region.String() {
+		return nil, errors.New("VPC: " + nameOrID + " is in " + vpc.RegionSlug + ", not " + region.String())
+	}
+
+	return vpc, nil
+}
@@ -0,0 +1,141 @@
+package dog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var TestVPC = godo.VPC{
+	ID:         "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+	Name:       "test-vpc",
+	IPRange:    "10.10.10.0/24",
+	RegionSlug: "nyc2",
+}
+
+var TestVPCs = []*godo.VPC{&TestVPC}
+
+var TestVPCMember = godo.VPCMember{
+	URN:  "do:droplet:1",
+	Name: "test.example.com",
+}
+
+var TestVPCMembers = []*godo.VPCMember{&TestVPCMember}
+
+var TestCreateVPCRequest = CreateVPCRequest{
+	Name:    "test-vpc",
+	Region:  NYC2,
+	IPRange: "10.10.10.0/24",
+}
+
+func TestGetAllVPCs(t *testing.T) {
+
+	vpcClient := NewVPCC(TestPAT)
+	vpcClient.client = &MockGodoVPCSvc{}
+
+	expected := TestVPCs
+	returned, _ := vpcClient.GetAllVPCs(FindAllVPCsRequest{Page: 1, PerPage: 5})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestCreateVPC(t *testing.T) {
+
+	vpcClient := NewVPCC(TestPAT)
+	vpcClient.client = &MockGodoVPCSvc{}
+
+	expected := &TestVPC
+	returned, _ := vpcClient.CreateVPC(TestCreateVPCRequest)
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestFindVPC(t *testing.T) {
+
+	vpcClient := NewVPCC(TestPAT)
+	vpcClient.client = &MockGodoVPCSvc{}
+
+	t.Run("By name", func(t *testing.T) {
+		returned, _ := vpcClient.FindVPC("test-vpc")
+		if !reflect.DeepEqual(&TestVPC, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", &TestVPC, returned)
+		}
+	})
+
+	t.Run("By UUID", func(t *testing.T) {
+		returned, _ := vpcClient.FindVPC(TestVPC.ID)
+		if !reflect.DeepEqual(&TestVPC, returned) {
+			t.Errorf("expected %+v\n , returned, %+v\n ", &TestVPC, returned)
+		}
+	})
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		expectedError := "VPC: missing, was not found"
+		_, returnedError := vpcClient.FindVPC("missing")
+		if returnedError == nil || expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+func TestGetVPCMembers(t *testing.T) {
+
+	vpcClient := NewVPCC(TestPAT)
+	vpcClient.client = &MockGodoVPCSvc{}
+
+	expected := TestVPCMembers
+	returned, _ := vpcClient.GetVPCMembers(FindVPCMembersRequest{ID: TestVPC.ID})
+	if !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected %+v\n , returned, %+v\n ", expected, returned)
+	}
+
+}
+
+func TestDeleteVPC(t *testing.T) {
+
+	t.Run("Error is thrown", func(t *testing.T) {
+		vpcClient := NewVPCC(TestPAT)
+		vpcClient.client = &MockGodoVPCSvc{}
+
+		expectedError := "Unable to delete VPC with id: " + TestVPC.ID + ". Godo error: " + TestError
+		returnedError := vpcClient.DeleteVPC(DeleteVPCRequest{ID: TestVPC.ID})
+		if expectedError != returnedError.Error() {
+			t.Errorf("expected error: %s returned error: %s", expectedError, returnedError)
+		}
+	})
+
+}
+
+type MockGodoVPCSvc struct{}
+
+func (m *MockGodoVPCSvc) Create(context.Context, *godo.VPCCreateRequest) (*godo.VPC, *godo.Response, error) {
+	return &TestVPC, nil, nil
+}
+
+func (m *MockGodoVPCSvc) Get(context.Context, string) (*godo.VPC, *godo.Response, error) {
+	return &TestVPC, nil, nil
+}
+
+func (m *MockGodoVPCSvc) List(context.Context, *godo.ListOptions) ([]*godo.VPC, *godo.Response, error) {
+	return TestVPCs, nil, nil
+}
+
+func (m *MockGodoVPCSvc) ListMembers(context.Context, string, *godo.VPCListMembersRequest, *godo.ListOptions) ([]*godo.VPCMember, *godo.Response, error) {
+	return TestVPCMembers, nil, nil
+}
+
+func (m *MockGodoVPCSvc) Update(context.Context, string, *godo.VPCUpdateRequest) (*godo.VPC, *godo.Response, error) {
+	return &TestVPC, nil, nil
+}
+
+func (m *MockGodoVPCSvc) Delete(context.Context, string) (*godo.Response, error) {
+	return nil, errors.New(TestError)
+}